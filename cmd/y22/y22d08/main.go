@@ -6,6 +6,9 @@ import (
 	"io"
 	"log"
 	"os"
+
+	"aoc/pkg/prefix"
+	"aoc/pkg/vec"
 )
 
 func main() {
@@ -15,41 +18,26 @@ func main() {
 
 func partOne() {
 	forest := readForest()
-	isVisible := map[uint64]struct{}{}
-
-	top := newBorder(len(forest))
-	left := newBorder(len(forest[0]))
-
-	for y := 0; y < len(forest); y++ {
-		for x := 0; x < len(forest[0]); x++ {
-			tree := forest[y][x]
 
-			if top[x] < tree || left[y] < tree {
-				isVisible[(uint64(y)<<32)|uint64(x)] = struct{}{}
-			}
-
-			top[x] = max(top[x], tree)
-			left[y] = max(left[y], tree)
-		}
+	// the highest tree on the way in from each side
+	var highest [][][]int8
+	for _, d := range vec.Cardinals {
+		highest = append(highest, prefix.RunningMax(forest, d, -1))
 	}
 
-	bottom := newBorder(len(forest))
-	right := newBorder(len(forest[0]))
-
-	for y := len(forest) - 1; y >= 0; y-- {
-		for x := len(forest[0]) - 1; x >= 0; x-- {
-			tree := forest[y][x]
-
-			if bottom[x] < tree || right[y] < tree {
-				isVisible[(uint64(y)<<32)|uint64(x)] = struct{}{}
+	visible := 0
+	for y, row := range forest {
+		for x, tree := range row {
+			for _, h := range highest {
+				if h[y][x] < tree {
+					visible++
+					break
+				}
 			}
-
-			bottom[x] = max(bottom[x], tree)
-			right[y] = max(right[y], tree)
 		}
 	}
 
-	fmt.Printf("%d\n", len(isVisible))
+	fmt.Printf("%d\n", visible)
 }
 
 func partTwo() {
@@ -148,18 +136,3 @@ func readForest() [][]int8 {
 	}
 	return forest
 }
-
-func max(a, b int8) int8 {
-	if a > b {
-		return a
-	}
-	return b
-}
-
-func newBorder(l int) []int8 {
-	s := make([]int8, l)
-	for i := range s {
-		s[i] = -1
-	}
-	return s
-}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"io"
 	"os"
+
+	"aoc/pkg/vec"
 )
 
 func main() {
@@ -22,11 +24,11 @@ func partOne(m *Map) {
 }
 
 func partTwo(m *Map) {
-	var shortest []Vec2i
+	var shortest []vec.Vec2i
 	for y, row := range m.HeightMap {
 		for x, e := range row {
 			if e == 0 {
-				p, err := walk(m, Vec2i{x, y}, m.Target)
+				p, err := walk(m, vec.Vec2i{X: x, Y: y}, m.Target)
 				if err != nil {
 					continue
 				}
@@ -39,10 +41,10 @@ func partTwo(m *Map) {
 	fmt.Printf("%d\n", len(shortest)-1)
 }
 
-func walk(m *Map, start Vec2i, target Vec2i) ([]Vec2i, error) {
+func walk(m *Map, start vec.Vec2i, target vec.Vec2i) ([]vec.Vec2i, error) {
 	h := &Queue{}
 
-	visited := map[Vec2i]Vec2i{}
+	visited := map[vec.Vec2i]vec.Vec2i{}
 	h.Push(start, start)
 
 	for {
@@ -65,8 +67,8 @@ func walk(m *Map, start Vec2i, target Vec2i) ([]Vec2i, error) {
 	return calculatePath(visited, target), nil
 }
 
-func calculatePath(visited map[Vec2i]Vec2i, end Vec2i) []Vec2i {
-	path := []Vec2i{end}
+func calculatePath(visited map[vec.Vec2i]vec.Vec2i, end vec.Vec2i) []vec.Vec2i {
+	path := []vec.Vec2i{end}
 	pos := end
 	for {
 		prev, ok := visited[pos]
@@ -94,20 +96,20 @@ func readHeightMap(fname string) *Map {
 	var heightMap [][]int
 	var row []int
 
-	var start, target Vec2i
+	var start, target vec.Vec2i
 	for {
 		b, err := reader.ReadByte()
 		if err == io.EOF {
 			heightMap = append(heightMap, row)
 			break
 		} else if b == 'S' {
-			start = Vec2i{
+			start = vec.Vec2i{
 				X: len(row),
 				Y: len(heightMap),
 			}
 			row = append(row, 0)
 		} else if b == 'E' {
-			target = Vec2i{
+			target = vec.Vec2i{
 				X: len(row),
 				Y: len(heightMap),
 			}
@@ -127,29 +129,15 @@ func readHeightMap(fname string) *Map {
 	}
 }
 
-type Vec2i struct{ X, Y int }
-
-func (v Vec2i) Add(summand Vec2i) Vec2i {
-	return Vec2i{
-		X: v.X + summand.X,
-		Y: v.Y + summand.Y,
-	}
-}
-
 type Map struct {
 	HeightMap [][]int
-	Start     Vec2i
-	Target    Vec2i
+	Start     vec.Vec2i
+	Target    vec.Vec2i
 }
 
-func (m *Map) MovesFrom(p Vec2i) []Vec2i {
-	targets := []Vec2i{
-		p.Add(Vec2i{X: 1, Y: 0}),
-		p.Add(Vec2i{-1, 0}),
-		p.Add(Vec2i{X: 0, Y: 1}),
-		p.Add(Vec2i{0, -1}),
-	}
-	var inReach []Vec2i
+func (m *Map) MovesFrom(p vec.Vec2i) []vec.Vec2i {
+	targets := p.Neighbors4()
+	var inReach []vec.Vec2i
 	for _, t := range targets {
 		if t.X < 0 || t.X >= len(m.HeightMap[0]) || t.Y < 0 || t.Y >= len(m.HeightMap) {
 			continue
@@ -162,21 +150,21 @@ func (m *Map) MovesFrom(p Vec2i) []Vec2i {
 	return inReach
 }
 
-type Queue [][]Vec2i
+type Queue [][]vec.Vec2i
 
-func (h *Queue) Push(position, previous Vec2i) {
-	*h = append(*h, []Vec2i{position, previous})
+func (h *Queue) Push(position, previous vec.Vec2i) {
+	*h = append(*h, []vec.Vec2i{position, previous})
 }
 
-func (h *Queue) PushAll(start Vec2i, elements []Vec2i) {
+func (h *Queue) PushAll(start vec.Vec2i, elements []vec.Vec2i) {
 	for _, e := range elements {
 		h.Push(e, start)
 	}
 }
 
-func (h *Queue) Pop() (Vec2i, Vec2i, bool) {
+func (h *Queue) Pop() (vec.Vec2i, vec.Vec2i, bool) {
 	if len(*h) == 0 {
-		return Vec2i{}, Vec2i{}, false
+		return vec.Vec2i{}, vec.Vec2i{}, false
 	}
 
 	v := (*h)[0]
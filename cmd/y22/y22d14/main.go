@@ -2,14 +2,23 @@ package main
 
 import (
 	"bufio"
+	"flag"
 	"fmt"
+	"image/color"
+	"io"
 	"log"
 	"math"
 	"os"
 	"strings"
+
+	"aoc/pkg/render"
+	"aoc/pkg/vec"
 )
 
+var record = flag.String("record", "", "write the falling sand of part one as GIF, or as asciinema cast if it ends in .cast")
+
 func main() {
+	flag.Parse()
 	partOne()
 	partTwo()
 }
@@ -32,14 +41,23 @@ func partOne() {
 	cave := NewCavePartOne(paths)
 
 	// fmt.Printf("%s\n", cave.String())
+	var rec *render.Recorder
+	if *record != "" {
+		rec = render.NewRecorder(cavePalette, 4)
+	}
 	dropped := 0
 	for {
+		rec.Record(cave.String)
 		if !dropSand(cave) {
 			break
 		}
 		// fmt.Printf("%s\n", cave)
 		dropped++
 	}
+	rec.Final(cave.String)
+	if err := rec.WriteFile(*record); err != nil {
+		log.Fatal(err)
+	}
 	fmt.Printf("%d\n", dropped)
 }
 
@@ -56,7 +74,7 @@ func partTwo() {
 	fmt.Printf("%d\n", dropped)
 }
 
-func parsePaths(fname string) [][]Vec2i {
+func parsePaths(fname string) [][]vec.Vec2i {
 	file, err := os.Open(fname)
 	if err != nil {
 		log.Fatal(err)
@@ -65,13 +83,13 @@ func parsePaths(fname string) [][]Vec2i {
 
 	scanner := bufio.NewScanner(file)
 
-	paths := [][]Vec2i{}
+	paths := [][]vec.Vec2i{}
 	for scanner.Scan() {
 
 		text := scanner.Text()
 
 		splits := strings.Split(text, " -> ")
-		var path []Vec2i
+		var path []vec.Vec2i
 		for _, s := range splits {
 			var x, y int
 			_, err := fmt.Sscanf(s, "%d,%d", &x, &y)
@@ -79,7 +97,7 @@ func parsePaths(fname string) [][]Vec2i {
 				panic(err)
 			}
 
-			path = append(path, Vec2i{x, y})
+			path = append(path, vec.Vec2i{X: x, Y: y})
 		}
 		paths = append(paths, path)
 	}
@@ -91,43 +109,29 @@ func parsePaths(fname string) [][]Vec2i {
 	return paths
 }
 
-type AABB struct {
-	Origin, Size Vec2i
-}
-
-func (a *AABB) InBounds(p Vec2i) bool {
-	if p.X < a.Origin.X || p.X > a.Origin.X+a.Size.X {
-		return false
-	}
-	if p.Y < a.Origin.Y || p.Y > a.Origin.Y+a.Size.Y {
-		return false
-	}
-	return true
-}
-
 type Cave struct {
-	aabb AABB
+	aabb vec.AABB
 	cave [][]Material
 }
 
-func (c *Cave) Get(p Vec2i) Material {
-	if !c.aabb.InBounds(p) {
+func (c *Cave) Get(p vec.Vec2i) Material {
+	if !c.aabb.Contains(p) {
 		return VOID
 	}
-	adjusted := p.Sub(c.aabb.Origin)
+	adjusted := p.Sub(c.aabb.From)
 	return c.cave[adjusted.Y][adjusted.X]
 }
 
-func (c *Cave) Set(p Vec2i, m Material) {
-	if !c.aabb.InBounds(p) {
+func (c *Cave) Set(p vec.Vec2i, m Material) {
+	if !c.aabb.Contains(p) {
 		panic("out of bounds")
 	}
-	adjusted := p.Sub(c.aabb.Origin)
+	adjusted := p.Sub(c.aabb.From)
 	c.cave[adjusted.Y][adjusted.X] = m
 }
 
-func (c *Cave) DrawRocks(start, end Vec2i) {
-	if !c.aabb.InBounds(start) || !c.aabb.InBounds(end) {
+func (c *Cave) DrawRocks(start, end vec.Vec2i) {
+	if !c.aabb.Contains(start) || !c.aabb.Contains(end) {
 		panic(fmt.Errorf("vectors out of bounds: %s -> %s", start, end))
 	}
 
@@ -138,7 +142,7 @@ func (c *Cave) DrawRocks(start, end Vec2i) {
 			b, e = e, b
 		}
 		for dx := b; dx <= e; dx++ {
-			c.Set(Vec2i{dx, start.Y}, ROCK)
+			c.Set(vec.Vec2i{X: dx, Y: start.Y}, ROCK)
 		}
 	} else if start.Y != end.Y {
 		b := start.Y
@@ -147,7 +151,7 @@ func (c *Cave) DrawRocks(start, end Vec2i) {
 			b, e = e, b
 		}
 		for dy := b; dy <= e; dy++ {
-			c.Set(Vec2i{start.X, dy}, ROCK)
+			c.Set(vec.Vec2i{X: start.X, Y: dy}, ROCK)
 		}
 	} else {
 		panic("invalid path")
@@ -173,28 +177,46 @@ func (c *Cave) String() string {
 	return buf.String()
 }
 
-func NewCavePartOne(paths [][]Vec2i) *Cave {
-	maxVec := Vec2i{math.MinInt, math.MinInt}
-	minVec := Vec2i{math.MaxInt, 0}
+var cavePalette = render.Palette{
+	'.': render.White,
+	'#': render.Gray,
+	'O': render.Yellow,
+}
+
+var materialColors = [...]color.Color{
+	AIR:  render.White,
+	ROCK: render.Gray,
+	SAND: render.Yellow,
+	VOID: render.Black,
+}
+
+// Render writes the cave as PNG, for caves too large to print.
+func (c *Cave) Render(w io.Writer) error {
+	m := render.Grid(c.cave, 4, func(m Material) color.Color {
+		return materialColors[m]
+	})
+	return m.EncodePNG(w)
+}
+
+func NewCavePartOne(paths [][]vec.Vec2i) *Cave {
+	maxVec := vec.Vec2i{X: math.MinInt, Y: math.MinInt}
+	minVec := vec.Vec2i{X: math.MaxInt, Y: 0}
 	for _, path := range paths {
 		for _, point := range path {
-			maxVec = max2i(maxVec, point)
-			minVec = min2i(minVec, point)
+			maxVec = maxVec.Max(point)
+			minVec = minVec.Min(point)
 		}
 	}
 
 	origin := minVec
-	size := maxVec.Sub(minVec).Add(Vec2i{1, 1})
+	size := maxVec.Sub(minVec).Add(vec.Vec2i{X: 1, Y: 1})
 
 	caveMaterial := make([][]Material, size.Y)
 	for y := 0; y < len(caveMaterial); y++ {
 		caveMaterial[y] = make([]Material, size.X)
 	}
 	cave := &Cave{
-		aabb: AABB{
-			Origin: origin,
-			Size:   size,
-		},
+		aabb: vec.NewAABBFromSize(origin, size),
 		cave: caveMaterial,
 	}
 	for _, path := range paths {
@@ -208,20 +230,20 @@ func NewCavePartOne(paths [][]Vec2i) *Cave {
 	return cave
 }
 
-func NewCavePartTwo(paths [][]Vec2i) *Cave {
-	maxVec := Vec2i{math.MinInt, math.MinInt}
-	minVec := Vec2i{math.MaxInt, 0}
+func NewCavePartTwo(paths [][]vec.Vec2i) *Cave {
+	maxVec := vec.Vec2i{X: math.MinInt, Y: math.MinInt}
+	minVec := vec.Vec2i{X: math.MaxInt, Y: 0}
 	for _, path := range paths {
 		for _, point := range path {
-			maxVec = max2i(maxVec, point)
-			minVec = min2i(minVec, point)
+			maxVec = maxVec.Max(point)
+			minVec = minVec.Min(point)
 		}
 	}
 
-	origin := Vec2i{0, 0}
+	origin := vec.Vec2i{X: 0, Y: 0}
 	// extra layer
-	size := maxVec.Sub(origin).Add(Vec2i{1, 1 + 1})
-	size = size.Add(Vec2i{size.Y, 0})
+	size := maxVec.Sub(origin).Add(vec.Vec2i{X: 1, Y: 1 + 1})
+	size = size.Add(vec.Vec2i{X: size.Y, Y: 0})
 
 	// add some extra X
 
@@ -230,10 +252,7 @@ func NewCavePartTwo(paths [][]Vec2i) *Cave {
 		caveMaterial[y] = make([]Material, size.X)
 	}
 	cave := &Cave{
-		aabb: AABB{
-			Origin: origin,
-			Size:   size,
-		},
+		aabb: vec.NewAABBFromSize(origin, size),
 		cave: caveMaterial,
 	}
 	for _, path := range paths {
@@ -248,7 +267,7 @@ func NewCavePartTwo(paths [][]Vec2i) *Cave {
 }
 
 func dropSand(cave *Cave) bool {
-	pos := Vec2i{500, cave.aabb.Origin.Y}
+	pos := vec.Vec2i{X: 500, Y: cave.aabb.From.Y}
 	m := cave.Get(pos)
 	if m != AIR {
 		panic("can't drop sand")
@@ -258,12 +277,12 @@ func dropSand(cave *Cave) bool {
 			return false
 		}
 
-		if !cave.Get(pos.Add(Vec2i{0, 1})).IsSolid() {
-			pos = pos.Add(Vec2i{0, 1})
-		} else if !cave.Get(pos.Add(Vec2i{-1, 1})).IsSolid() {
-			pos = pos.Add(Vec2i{-1, 1})
-		} else if !cave.Get(pos.Add(Vec2i{1, 1})).IsSolid() {
-			pos = pos.Add(Vec2i{1, 1})
+		if !cave.Get(pos.Add(vec.Vec2i{X: 0, Y: 1})).IsSolid() {
+			pos = pos.Add(vec.Vec2i{X: 0, Y: 1})
+		} else if !cave.Get(pos.Add(vec.Vec2i{X: -1, Y: 1})).IsSolid() {
+			pos = pos.Add(vec.Vec2i{X: -1, Y: 1})
+		} else if !cave.Get(pos.Add(vec.Vec2i{X: 1, Y: 1})).IsSolid() {
+			pos = pos.Add(vec.Vec2i{X: 1, Y: 1})
 		} else {
 			cave.Set(pos, SAND)
 			return true
@@ -272,72 +291,28 @@ func dropSand(cave *Cave) bool {
 }
 
 func dropSandPartTwo(cave *Cave) bool {
-	pos := Vec2i{500, cave.aabb.Origin.Y}
+	pos := vec.Vec2i{X: 500, Y: cave.aabb.From.Y}
 	m := cave.Get(pos)
 	if m == SAND {
 		return false
 	}
-	floorY := cave.aabb.Origin.Y + cave.aabb.Size.Y
+	floorY := cave.aabb.To.Y + 1
 	for {
-		straightDown := pos.Add(Vec2i{0, 1})
+		straightDown := pos.Add(vec.Vec2i{X: 0, Y: 1})
 		if straightDown.Y >= floorY {
 			cave.Set(pos, SAND)
 			return true
 		}
 
-		if !cave.Get(pos.Add(Vec2i{0, 1})).IsSolid() {
-			pos = pos.Add(Vec2i{0, 1})
-		} else if !cave.Get(pos.Add(Vec2i{-1, 1})).IsSolid() {
-			pos = pos.Add(Vec2i{-1, 1})
-		} else if !cave.Get(pos.Add(Vec2i{1, 1})).IsSolid() {
-			pos = pos.Add(Vec2i{1, 1})
+		if !cave.Get(pos.Add(vec.Vec2i{X: 0, Y: 1})).IsSolid() {
+			pos = pos.Add(vec.Vec2i{X: 0, Y: 1})
+		} else if !cave.Get(pos.Add(vec.Vec2i{X: -1, Y: 1})).IsSolid() {
+			pos = pos.Add(vec.Vec2i{X: -1, Y: 1})
+		} else if !cave.Get(pos.Add(vec.Vec2i{X: 1, Y: 1})).IsSolid() {
+			pos = pos.Add(vec.Vec2i{X: 1, Y: 1})
 		} else {
 			cave.Set(pos, SAND)
 			return true
 		}
 	}
 }
-
-func max2i(a, b Vec2i) Vec2i {
-	return Vec2i{
-		X: max(a.X, b.X),
-		Y: max(a.Y, b.Y),
-	}
-}
-
-func min2i(a, b Vec2i) Vec2i {
-	return Vec2i{
-		X: min(a.X, b.X),
-		Y: min(a.Y, b.Y),
-	}
-}
-
-func max(a, b int) int {
-	if a > b {
-		return a
-	}
-	return b
-}
-
-func min(a, b int) int {
-	if a < b {
-		return a
-	}
-	return b
-}
-
-type Vec2i struct {
-	X, Y int
-}
-
-func (v Vec2i) String() string {
-	return fmt.Sprintf("(%d/%d)", v.X, v.Y)
-}
-
-func (v Vec2i) Add(summand Vec2i) Vec2i {
-	return Vec2i{X: v.X + summand.X, Y: v.Y + summand.Y}
-}
-
-func (v Vec2i) Sub(subtrahend Vec2i) Vec2i {
-	return Vec2i{X: v.X - subtrahend.X, Y: v.Y - subtrahend.Y}
-}
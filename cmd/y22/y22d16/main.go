@@ -12,6 +12,8 @@ import (
 	"slices"
 	"strings"
 	"time"
+
+	"aoc/pkg/graph"
 )
 
 //go:embed input.txt
@@ -140,9 +142,6 @@ func main() {
 func prepareGraph(valves map[string]*Valve) []ValveInt {
 	dumpParsedGraph(valves, "full.dot")
 
-	removeZeroFlows(valves)
-	dumpParsedGraph(valves, "nozero.dot")
-
 	mapped := mapGraph(valves)
 	dumpMappedGraph(mapped, "mapped.dot")
 	return mapped
@@ -352,38 +351,40 @@ func parseInput(r io.Reader) map[string]*Valve {
 	return valveMap
 }
 
+// mapGraph reduces the tunnels to the valves worth opening, 'AA' is always
+// mapped to 0 as that's where we start
 func mapGraph(valves map[string]*Valve) []ValveInt {
-	idSeq := uint8(0)
-	idToNum := map[string]uint8{"AA": idSeq}
-	idSeq++
-
-	mapped := make([]ValveInt, len(valves))
-
+	tunnels := graph.NewDirected[string]()
+	keys := []string{"AA"}
 	for _, v := range valves {
-		mappedId, ok := idToNum[v.ID]
-		if !ok {
-			mappedId = idSeq
-			idSeq++
-			idToNum[v.ID] = mappedId
+		tunnels.AddNode(v.ID)
+		for _, t := range v.Tunnels {
+			tunnels.AddWeightedEdge(v.ID, t.Destination, t.Cost)
+		}
+		if v.FlowRate > 0 && v.ID != "AA" {
+			keys = append(keys, v.ID)
 		}
+	}
+	slices.Sort(keys[1:])
+
+	distances := tunnels.Contract(keys)
 
+	mapped := make([]ValveInt, len(keys))
+	for i, id := range keys {
 		var mappedTunnels []*TunnelInt
-		for _, t := range v.Tunnels {
-			tId, ok := idToNum[t.Destination]
-			if !ok {
-				tId = idSeq
-				idSeq++
-				idToNum[t.Destination] = tId
+		for j, cost := range distances.Dist[i] {
+			if i == j || cost == graph.Unreachable {
+				continue
 			}
 			mappedTunnels = append(mappedTunnels, &TunnelInt{
-				Destination: tId,
-				Cost:        t.Cost,
+				Destination: uint8(j),
+				Cost:        cost,
 			})
 		}
 
-		mapped[mappedId] = ValveInt{
-			ID:       mappedId,
-			FlowRate: v.FlowRate,
+		mapped[i] = ValveInt{
+			ID:       uint8(i),
+			FlowRate: valves[id].FlowRate,
 			Tunnels:  mappedTunnels,
 		}
 	}
@@ -391,64 +392,6 @@ func mapGraph(valves map[string]*Valve) []ValveInt {
 	return mapped
 }
 
-func removeZeroFlows(valves map[string]*Valve) {
-	var valveList []*Valve
-	for _, v := range valves {
-		valveList = append(valveList, v)
-	}
-	for _, v := range valveList {
-		if v.FlowRate == 0 && v.ID != "AA" {
-			removeValve(valves, v)
-		}
-	}
-}
-
-func removeValve(valves map[string]*Valve, v *Valve) {
-	for i, t1 := range v.Tunnels {
-		for j := i + 1; j < len(v.Tunnels); j++ {
-			t2 := v.Tunnels[j]
-			if t1 == nil || t2 == nil {
-				panic("wtf?")
-			}
-			if t1.Destination == t2.Destination {
-				continue
-			}
-
-			cost := t1.Cost + t2.Cost
-
-			v1 := valves[t1.Destination]
-			v2 := valves[t2.Destination]
-
-			// do we already have a connection?
-			idx1 := slices.IndexFunc(v1.Tunnels, matchDestination(t2.Destination))
-			idx2 := slices.IndexFunc(v2.Tunnels, matchDestination(t1.Destination))
-
-			if idx1 >= 0 {
-				existingCost := v1.Tunnels[idx1].Cost
-				newCost := min(cost, existingCost)
-				v1.Tunnels[idx1].Cost = newCost
-				v2.Tunnels[idx2].Cost = newCost
-			} else {
-				v1.Tunnels = append(v1.Tunnels, &Tunnel{Destination: v2.ID, Cost: cost})
-				v2.Tunnels = append(v2.Tunnels, &Tunnel{Destination: v1.ID, Cost: cost})
-			}
-		}
-	}
-	for _, t := range v.Tunnels {
-		other := valves[t.Destination]
-		idx := slices.IndexFunc(other.Tunnels, func(t *Tunnel) bool { return t.Destination == v.ID })
-		if idx >= 0 {
-			newTunnels := slices.Delete(other.Tunnels, idx, idx+1)
-			other.Tunnels = newTunnels
-		}
-	}
-	delete(valves, v.ID)
-}
-
-func matchDestination(s string) func(t *Tunnel) bool {
-	return func(t *Tunnel) bool { return t.Destination == s }
-}
-
 func max(a, b int) int {
 	if a > b {
 		return a
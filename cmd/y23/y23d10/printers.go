@@ -2,12 +2,33 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"slices"
 	"strings"
 
+	"aoc/pkg/render"
 	"aoc/pkg/vec"
 )
 
+var pipePalette = render.Palette{
+	'.': render.White,
+	'S': render.Red,
+	'|': render.Gray,
+	'-': render.Gray,
+	'L': render.Gray,
+	'J': render.Gray,
+	'7': render.Gray,
+	'F': render.Gray,
+}
+
+// renderFieldWithPath writes the field as PNG with the path drawn on top,
+// for inputs too large to print.
+func renderFieldWithPath(w io.Writer, field [][]byte, path []vec.Vec2i) error {
+	m := render.Bytes(field, 6, pipePalette)
+	m.Path(path, render.Blue)
+	return m.EncodePNG(w)
+}
+
 func printFieldWithPath(field [][]byte, path []vec.Vec2i) {
 	for y, line := range field {
 		fmt.Printf("%2d ", y)
@@ -67,28 +88,3 @@ func printHeader(ident, max int) {
 	}
 	fmt.Println()
 }
-
-func printSegments(segments []*Segment) {
-	aabb := boundingBox(segments)
-	fmt.Printf("%v\n", aabb)
-
-	printHeader(3, aabb.To.X)
-	for y := 0; y <= aabb.To.Y; y++ {
-		fmt.Printf("%2d ", y)
-		for x := 0; x <= aabb.To.X; x++ {
-
-			intersection := stabsAnySegment(segments, vec.Vec2i{x, y})
-
-			if intersection == IntersectsInside {
-				fmt.Print("|")
-			} else if intersection == IntersectsEnd {
-				fmt.Print("v")
-			} else if intersection == IntersectsStart {
-				fmt.Print("^")
-			} else {
-				fmt.Print(".")
-			}
-		}
-		fmt.Println()
-	}
-}
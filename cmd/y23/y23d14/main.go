@@ -3,16 +3,28 @@ package main
 import (
 	"bufio"
 	"embed"
+	"flag"
 	"fmt"
 	"io/fs"
+	"log"
 
 	"aoc/pkg/in"
+	"aoc/pkg/render"
 )
 
 //go:embed *.txt
 var inputs embed.FS
 
+var record = flag.String("record", "", "write the spin cycles of part two as GIF, or as asciinema cast if it ends in .cast")
+
+var tilePalette = render.Palette{
+	rune(TileAir):       render.White,
+	rune(TileRoundRock): render.Gray,
+	rune(TileCubeRock):  render.Black,
+}
+
 func main() {
+	flag.Parse()
 	partOne()
 	partTwo()
 }
@@ -24,9 +36,20 @@ func partTwo() {
 	raw := parse(file)
 	tiles := NewTiles(raw)
 
+	var rec *render.Recorder
+	if *record != "" {
+		rec = render.NewRecorder(tilePalette, 4)
+	}
+	defer func() {
+		if err := rec.WriteFile(*record); err != nil {
+			log.Fatal(err)
+		}
+	}()
+
 	totalCycles := 1_000_000_000
 	seen := map[string]int{}
 	for i := 0; i < totalCycles; i++ {
+		rec.Record(tiles.Frame)
 
 		tiles = TiltCycle(tiles)
 		k := tiles.Hash()
@@ -35,6 +58,7 @@ func partTwo() {
 			w := WeighTiles(tiles)
 			loopLen := i - prev
 			if (totalCycles-i-1)%loopLen == 0 {
+				rec.Final(tiles.Frame)
 				fmt.Printf("part two: %d\n", w)
 				if w != 104533 {
 					panic("bad result")
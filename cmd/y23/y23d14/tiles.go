@@ -181,6 +181,19 @@ func (t *Tiles) Set(x, y int, v Tile) {
 	t.backing[y*t.m+x] = v
 }
 
+// Frame returns the tiles as text without row numbers, for recording.
+func (t *Tiles) Frame() string {
+	var buf strings.Builder
+	m, n := t.Size()
+	for y := 0; y < n; y++ {
+		for x := 0; x < m; x++ {
+			buf.WriteByte(t.Get(x, y))
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.String()
+}
+
 func (t *Tiles) String() string {
 	var buf strings.Builder
 	m, n := t.Size()
@@ -8,7 +8,6 @@ import (
 	"runtime"
 	"strings"
 
-	"aoc/pkg/sets"
 	"aoc/pkg/vec"
 
 	"aoc/pkg/in"
@@ -21,7 +20,7 @@ type Part byte
 
 type Contraption struct {
 	Parts       [][]Part
-	Light       [][]sets.Set[vec.Vec2i]
+	Light       [][]uint8
 	BoundingBox vec.AABB
 }
 
@@ -49,14 +48,12 @@ func CopyContraption(c *Contraption) *Contraption {
 	}
 }
 
-func makeLights(size vec.Vec2i) [][]sets.Set[vec.Vec2i] {
-	lights := make([][]sets.Set[vec.Vec2i], size.Y)
+// makeLights creates a grid of bitmasks, tracking the headings of all beams
+// that passed
+func makeLights(size vec.Vec2i) [][]uint8 {
+	lights := make([][]uint8, size.Y)
 	for i := range lights {
-		row := make([]sets.Set[vec.Vec2i], size.X)
-		for j := range row {
-			row[j] = make(sets.Set[vec.Vec2i])
-		}
-		lights[i] = row
+		lights[i] = make([]uint8, size.X)
 	}
 	return lights
 }
@@ -72,13 +69,13 @@ func (c *Contraption) GetPart(p vec.Vec2i) Part {
 	return PartVoid
 }
 
-func (c *Contraption) AddLight(p vec.Vec2i, heading vec.Vec2i) bool {
+func (c *Contraption) AddLight(p vec.Vec2i, heading vec.Dir) bool {
 	if c.BoundingBox.Contains(p) {
-		set := c.Light[p.Y][p.X]
-		if set.Has(heading) {
+		mask := c.Light[p.Y][p.X]
+		if mask&heading.Bit() != 0 {
 			return false
 		}
-		set.Put(heading)
+		c.Light[p.Y][p.X] = mask | heading.Bit()
 		return true
 	}
 	return false
@@ -88,7 +85,7 @@ func (c *Contraption) LightCount() int {
 	sum := 0
 	for _, row := range c.Light {
 		for _, l := range row {
-			if len(l) > 0 {
+			if l != 0 {
 				sum++
 			}
 		}
@@ -120,7 +117,7 @@ func partTwo() {
 
 	size := contraption.Size()
 
-	tasks := make(chan []vec.Vec2i, size.X*2+size.Y*2+1)
+	tasks := make(chan *beam, size.X*2+size.Y*2+1)
 
 	results := make(chan int, 256)
 
@@ -137,19 +134,19 @@ func partTwo() {
 				}
 
 				c := CopyContraption(contraption)
-				traceBeam(c, t[0], t[1])
+				traceBeam(c, t.pos, t.heading)
 				results <- c.LightCount()
 			}
 		}()
 	}
 
 	for x := 0; x < size.X; x++ {
-		tasks <- []vec.Vec2i{{X: x}, HeadDown}
-		tasks <- []vec.Vec2i{{x, size.Y - 1}, HeadUp}
+		tasks <- &beam{vec.Vec2i{X: x}, vec.S}
+		tasks <- &beam{vec.Vec2i{X: x, Y: size.Y - 1}, vec.N}
 	}
 	for y := 0; y < size.Y; y++ {
-		tasks <- []vec.Vec2i{{0, y}, HeadRight}
-		tasks <- []vec.Vec2i{{size.X - 1, y}, HeadLeft}
+		tasks <- &beam{vec.Vec2i{X: 0, Y: y}, vec.E}
+		tasks <- &beam{vec.Vec2i{X: size.X - 1, Y: y}, vec.W}
 	}
 
 	tasks <- nil
@@ -181,7 +178,7 @@ func partOne() {
 
 	contraption := NewContraption(parts)
 
-	traceBeam(contraption, vec.Vec2i{0, 0}, HeadRight)
+	traceBeam(contraption, vec.Vec2i{}, vec.E)
 
 	sum := contraption.LightCount()
 	fmt.Printf("part one: %d\n", sum)
@@ -191,7 +188,12 @@ func partOne() {
 	}
 }
 
-func traceBeam(c *Contraption, position, heading vec.Vec2i) {
+type beam struct {
+	pos     vec.Vec2i
+	heading vec.Dir
+}
+
+func traceBeam(c *Contraption, position vec.Vec2i, heading vec.Dir) {
 	ok := c.AddLight(position, heading)
 	if !ok {
 		return
@@ -200,56 +202,49 @@ func traceBeam(c *Contraption, position, heading vec.Vec2i) {
 	part := c.GetPart(position)
 	headings := getHeadings(part, heading)
 	for _, h := range headings {
-		next := position.Add(h)
+		next := position.Move(h)
 		traceBeam(c, next, h)
 	}
 }
 
 type key struct {
 	P Part
-	H vec.Vec2i
+	H vec.Dir
 }
 
-var (
-	HeadUp    = vec.Vec2i{0, -1}
-	HeadDown  = vec.Vec2i{0, 1}
-	HeadRight = vec.Vec2i{1, 0}
-	HeadLeft  = vec.Vec2i{-1, 0}
-)
-
-var headingLut = map[key][]vec.Vec2i{
-	{PartAir, HeadUp}:    {HeadUp},
-	{PartAir, HeadDown}:  {HeadDown},
-	{PartAir, HeadLeft}:  {HeadLeft},
-	{PartAir, HeadRight}: {HeadRight},
-
-	{PartHSplitter, HeadUp}:    {HeadLeft, HeadRight},
-	{PartHSplitter, HeadDown}:  {HeadLeft, HeadRight},
-	{PartHSplitter, HeadLeft}:  {HeadLeft},
-	{PartHSplitter, HeadRight}: {HeadRight},
-
-	{PartVSplitter, HeadUp}:    {HeadUp},
-	{PartVSplitter, HeadDown}:  {HeadDown},
-	{PartVSplitter, HeadLeft}:  {HeadUp, HeadDown},
-	{PartVSplitter, HeadRight}: {HeadUp, HeadDown},
-
-	{PartMirrorUp, HeadUp}:    {HeadRight},
-	{PartMirrorUp, HeadDown}:  {HeadLeft},
-	{PartMirrorUp, HeadLeft}:  {HeadDown},
-	{PartMirrorUp, HeadRight}: {HeadUp},
-
-	{PartMirrorDown, HeadUp}:    {HeadLeft},
-	{PartMirrorDown, HeadDown}:  {HeadRight},
-	{PartMirrorDown, HeadLeft}:  {HeadUp},
-	{PartMirrorDown, HeadRight}: {HeadDown},
-
-	{PartVoid, HeadUp}:    {},
-	{PartVoid, HeadDown}:  {},
-	{PartVoid, HeadLeft}:  {},
-	{PartVoid, HeadRight}: {},
+var headingLut = map[key][]vec.Dir{
+	{PartAir, vec.N}: {vec.N},
+	{PartAir, vec.S}: {vec.S},
+	{PartAir, vec.W}: {vec.W},
+	{PartAir, vec.E}: {vec.E},
+
+	{PartHSplitter, vec.N}: {vec.W, vec.E},
+	{PartHSplitter, vec.S}: {vec.W, vec.E},
+	{PartHSplitter, vec.W}: {vec.W},
+	{PartHSplitter, vec.E}: {vec.E},
+
+	{PartVSplitter, vec.N}: {vec.N},
+	{PartVSplitter, vec.S}: {vec.S},
+	{PartVSplitter, vec.W}: {vec.N, vec.S},
+	{PartVSplitter, vec.E}: {vec.N, vec.S},
+
+	{PartMirrorUp, vec.N}: {vec.E},
+	{PartMirrorUp, vec.S}: {vec.W},
+	{PartMirrorUp, vec.W}: {vec.S},
+	{PartMirrorUp, vec.E}: {vec.N},
+
+	{PartMirrorDown, vec.N}: {vec.W},
+	{PartMirrorDown, vec.S}: {vec.E},
+	{PartMirrorDown, vec.W}: {vec.N},
+	{PartMirrorDown, vec.E}: {vec.S},
+
+	{PartVoid, vec.N}: {},
+	{PartVoid, vec.S}: {},
+	{PartVoid, vec.W}: {},
+	{PartVoid, vec.E}: {},
 }
 
-func getHeadings(part Part, heading vec.Vec2i) []vec.Vec2i {
+func getHeadings(part Part, heading vec.Dir) []vec.Dir {
 	headings, ok := headingLut[key{P: part, H: heading}]
 	if !ok {
 		panic("wut?")
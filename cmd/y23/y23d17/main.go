@@ -12,6 +12,7 @@ import (
 
 	"aoc/pkg/in"
 	"aoc/pkg/queue"
+	"aoc/pkg/render"
 	"aoc/pkg/vec"
 )
 
@@ -37,7 +38,8 @@ func partTwo() {
 }
 
 type key struct {
-	Pos, Heading vec.Vec2i
+	Pos     vec.Vec2i
+	Heading vec.Dir
 }
 
 func partOne() {
@@ -59,16 +61,13 @@ func walk(island [][]uint8, skip int, maxSteps int) int {
 
 	best := make(map[key]int, 1024)
 
-	var todo queue.Queue[[]vec.Vec2i]
+	var todo queue.Queue[key]
 
-	k := key{Pos: vec.Vec2i{}, Heading: HeadRight}
-	best[k] = 0
-
-	k = key{Pos: vec.Vec2i{}, Heading: HeadDown}
-	best[k] = 0
-
-	todo.Push([]vec.Vec2i{{}, HeadRight})
-	todo.Push([]vec.Vec2i{{}, HeadDown})
+	for _, h := range []vec.Dir{vec.E, vec.S} {
+		k := key{Pos: vec.Vec2i{}, Heading: h}
+		best[k] = 0
+		todo.Push(k)
+	}
 
 	for {
 		current, ok := todo.Pop()
@@ -76,21 +75,20 @@ func walk(island [][]uint8, skip int, maxSteps int) int {
 			break
 		}
 
-		cpos := current[0]
-		cheading := current[1]
+		cpos := current.Pos
+		cheading := current.Heading
 
-		k := key{Pos: cpos, Heading: cheading}
-		cost, ok := best[k]
+		cost, ok := best[current]
 		cost = util.MustOk(cost, ok)
 
 		if cpos == boundingBox.To {
 			continue
 		}
 
-		headings := nextHeadings(cheading)
+		headings := []vec.Dir{cheading.TurnLeft(), cheading.TurnRight()}
 		for i := 0; i < maxSteps; i++ {
 
-			cpos = cpos.Add(cheading)
+			cpos = cpos.Move(cheading)
 			if !boundingBox.Contains(cpos) {
 				break
 			}
@@ -108,7 +106,7 @@ func walk(island [][]uint8, skip int, maxSteps int) int {
 				}
 				best[k] = cost
 
-				todo.Push([]vec.Vec2i{cpos, h})
+				todo.Push(k)
 			}
 		}
 	}
@@ -120,7 +118,7 @@ func walk(island [][]uint8, skip int, maxSteps int) int {
 
 func findMin(best map[key]int, dst vec.Vec2i) int {
 	heat := math.MaxInt
-	for _, h := range []vec.Vec2i{HeadUp, HeadLeft, HeadRight, HeadDown} {
+	for _, h := range vec.Cardinals {
 		b, ok := best[key{
 			Pos:     dst,
 			Heading: h,
@@ -132,24 +130,6 @@ func findMin(best map[key]int, dst vec.Vec2i) int {
 	return heat
 }
 
-var (
-	HeadUp    = vec.Vec2i{0, -1}
-	HeadDown  = vec.Vec2i{0, 1}
-	HeadLeft  = vec.Vec2i{-1, 0}
-	HeadRight = vec.Vec2i{1, 0}
-)
-
-var lut = map[vec.Vec2i][]vec.Vec2i{
-	HeadUp:    {HeadRight, HeadLeft},
-	HeadDown:  {HeadRight, HeadLeft},
-	HeadLeft:  {HeadUp, HeadDown},
-	HeadRight: {HeadUp, HeadDown},
-}
-
-func nextHeadings(heading vec.Vec2i) []vec.Vec2i {
-	return lut[heading]
-}
-
 func stringIsland(island [][]uint8) string {
 	var buf strings.Builder
 
@@ -163,6 +143,20 @@ func stringIsland(island [][]uint8) string {
 	return buf.String()
 }
 
+// renderBest writes the lowest heat loss per block as heatmap PNG.
+func renderBest(w io.Writer, size vec.Vec2i, best map[key]int) error {
+	heat := make(map[vec.Vec2i]int)
+	for k, b := range best {
+		if h, ok := heat[k.Pos]; !ok || b < h {
+			heat[k.Pos] = b
+		}
+	}
+
+	m := render.New(size, 4)
+	m.Heatmap(heat, render.Viridis)
+	return m.EncodePNG(w)
+}
+
 func stringBest(size vec.Vec2i, best map[key]int) string {
 	var buf strings.Builder
 
@@ -170,7 +164,7 @@ func stringBest(size vec.Vec2i, best map[key]int) string {
 		fmt.Fprintf(&buf, "%3d |", y)
 		for x := 0; x < size.X; x++ {
 			heat := math.MaxInt
-			for _, h := range []vec.Vec2i{HeadUp, HeadLeft, HeadRight, HeadDown} {
+			for _, h := range vec.Cardinals {
 				b, ok := best[key{
 					Pos:     vec.Vec2i{x, y},
 					Heading: h,
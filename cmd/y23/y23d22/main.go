@@ -2,10 +2,13 @@ package main
 
 import (
 	"bufio"
+	"bytes"
 	"cmp"
 	"embed"
+	"flag"
 	"fmt"
 	"io"
+	"log"
 	"os"
 	"slices"
 	"strconv"
@@ -23,7 +26,10 @@ import (
 //go:embed *.txt
 var inputs embed.FS
 
+var record = flag.Bool("record", false, "write the settled bricks of part one to viz_after.svg and viz_after.obj, and their settling to viz_stacking.gif")
+
 func main() {
+	flag.Parse()
 	partOne()
 	partTwo()
 }
@@ -105,7 +111,24 @@ func partOne() {
 
 	visualizedAfter := util.Must(os.Create("viz_after.png"))
 	defer visualizedAfter.Close()
-	RenderCuboids(visualizedAfter, cuboids)
+	RenderCuboids(visualizedAfter, stacked)
+
+	if *record {
+		writeFile("viz_after.svg", func(w io.Writer) error {
+			return RenderCuboidsSVG(w, stacked)
+		})
+		var materials bytes.Buffer
+		writeFile("viz_after.obj", func(w io.Writer) error {
+			return ExportCuboids(w, &materials, "viz_after.mtl", stacked)
+		})
+		writeFile("viz_after.mtl", func(w io.Writer) error {
+			_, err := materials.WriteTo(w)
+			return err
+		})
+		writeFile("viz_stacking.gif", func(w io.Writer) error {
+			return AnimateStacking(w, cuboids, stacked, 8)
+		})
+	}
 
 	sum := countNonStructuralBlocks(stacked)
 
@@ -116,6 +139,20 @@ func partOne() {
 	}
 }
 
+// writeFile creates path and fills it with write, any error is fatal.
+func writeFile(path string, write func(w io.Writer) error) {
+	f, err := os.Create(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := write(f); err != nil {
+		log.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		log.Fatal(err)
+	}
+}
+
 func countNonStructuralBlocks(cuboids []*iso3d.Cuboid) int {
 	integrities := calculateIntegrities(cuboids)
 
@@ -11,6 +11,7 @@ import (
 
 	"aoc/pkg/iso3d"
 	"aoc/pkg/util"
+	"aoc/pkg/vec"
 )
 
 var colorPalette = []color.RGBA{
@@ -33,8 +34,11 @@ func hexDecode(s string) color.RGBA {
 	return color.RGBA{r[0], g[0], b[0], 0xff}
 }
 
+// RenderCuboids draws the cuboids as PNG, small inputs such as the example
+// get their bricks labelled A, B, C and so on.
 func RenderCuboids(w io.Writer, cubes []*iso3d.Cuboid) error {
-	canvas := iso3d.NewCanvas(256, 1024*3)
+	canvas := iso3d.NewFittedCanvas(iso3d.DefaultCamera)
+	canvas.Options.Outline = color.Black
 
 	for i, cube := range cubes {
 		canvas.AddCube(&iso3d.Cuboid{
@@ -43,12 +47,25 @@ func RenderCuboids(w io.Writer, cubes []*iso3d.Cuboid) error {
 			Color:    colorPalette[i%len(colorPalette)],
 		})
 	}
+	if len(cubes) <= 26 {
+		for i, cube := range cubes {
+			canvas.AddLabel(topCenter(cube), string(rune('A'+i)))
+		}
+	}
 
 	canvas.Draw()
 
 	return png.Encode(w, canvas.AsImage())
 }
 
+func topCenter(cube *iso3d.Cuboid) vec.Vec3i {
+	return vec.Vec3i{
+		X: cube.Position.X + cube.Size.X/2,
+		Y: cube.Position.Y + cube.Size.Y/2,
+		Z: cube.Position.Z + cube.Size.Z,
+	}
+}
+
 func depthSort(cubes []*iso3d.Cuboid) {
 	slices.SortFunc(cubes, depthCmp)
 }
@@ -60,3 +77,62 @@ func depthCmp(a, b *iso3d.Cuboid) int {
 	}
 	return cmp.Compare(a.Position.Z, b.Position.Z)
 }
+
+// RenderCuboidsSVG writes the cuboids as zoomable SVG, hovering a brick
+// shows its position.
+func RenderCuboidsSVG(w io.Writer, cubes []*iso3d.Cuboid) error {
+	canvas := iso3d.NewFittedCanvas(iso3d.DefaultCamera)
+
+	for i, cube := range cubes {
+		canvas.AddCube(&iso3d.Cuboid{
+			Position: cube.Position,
+			Size:     cube.Size,
+			Color:    colorPalette[i%len(colorPalette)],
+		})
+	}
+
+	canvas.Options.Outline = color.Black
+	canvas.Options.OutlineWidth = 0.5
+	return canvas.EncodeSVG(w)
+}
+
+// ExportCuboids writes the cuboids as OBJ mesh with its material library
+// named mtlName, for viewing the stack in 3D.
+func ExportCuboids(obj, mtl io.Writer, mtlName string, cubes []*iso3d.Cuboid) error {
+	colored := make([]*iso3d.Cuboid, len(cubes))
+	for i, cube := range cubes {
+		colored[i] = &iso3d.Cuboid{
+			Position: cube.Position,
+			Size:     cube.Size,
+			Color:    colorPalette[i%len(colorPalette)],
+		}
+	}
+
+	return iso3d.NewMeshFromCuboids(colored).WriteOBJ(obj, mtl, mtlName)
+}
+
+// AnimateStacking renders the bricks settling as GIF, each of the frames
+// lets the next share of the falling bricks land on the stack.
+func AnimateStacking(w io.Writer, falling, stacked []*iso3d.Cuboid, frames int) error {
+	anim := iso3d.NewAnimation(iso3d.DefaultCamera)
+	anim.Delay = 50
+
+	anim.Record(frames+1, func(step int) []*iso3d.Cuboid {
+		settled := len(stacked) * step / frames
+
+		scene := make([]*iso3d.Cuboid, len(falling))
+		for i, cube := range falling {
+			if i < settled {
+				cube = stacked[i]
+			}
+			scene[i] = &iso3d.Cuboid{
+				Position: cube.Position,
+				Size:     cube.Size,
+				Color:    colorPalette[i%len(colorPalette)],
+			}
+		}
+		return scene
+	})
+
+	return anim.EncodeGIF(w)
+}
@@ -2,11 +2,14 @@ package main
 
 import (
 	"bufio"
+	"cmp"
 	"embed"
 	"fmt"
 	"io"
 
+	"aoc/pkg/graph"
 	"aoc/pkg/in"
+	"aoc/pkg/sets"
 	"aoc/pkg/sio"
 	"aoc/pkg/util"
 )
@@ -37,35 +40,25 @@ func partTwo() {
 	fmt.Printf("part two: %d\n", sum)
 }
 
-func buildRuleGraph(rules [][]int) map[int][]int {
-	graph := make(map[int][]int)
+func buildRuleGraph(rules [][]int) *graph.Graph[int] {
+	g := graph.NewDirected[int]()
 	for _, r := range rules {
-		graph[r[0]] = append(graph[r[0]], r[1])
+		g.AddEdge(r[0], r[1])
 	}
-	return graph
+	return g
 }
 
-func toposort(update []int, graph map[int][]int) []int {
-	inDegrees := make(map[int]int)
-	for _, page := range update {
-		edges := graph[page]
-		for _, edge := range edges {
-			inDegrees[edge] = inDegrees[edge] + 1
-		}
-	}
+func toposort(update []int, ruleGraph *graph.Graph[int]) []int {
+	pages := sets.New[int]()
+	pages.PutAll(update)
 
-	newInDegrees := make(map[int]int)
+	// only the rules between pages of this update apply
+	g := ruleGraph.Subgraph(pages.Has)
 	for _, page := range update {
-		count := inDegrees[page]
-		newInDegrees[page] = count
-	}
-
-	sorted := make([]int, len(update))
-	for node, inCount := range newInDegrees {
-		sorted[inCount] = node
+		g.AddNode(page)
 	}
 
-	return sorted
+	return util.Must(g.TopoSort(cmp.Compare[int]))
 }
 
 func filterUnsortedUpdate(updates [][]int, rules [][]int) [][]int {
@@ -3,18 +3,34 @@ package main
 import (
 	"bufio"
 	"embed"
+	"flag"
 	"fmt"
 	"io"
+	"log"
 	"time"
 
 	"aoc/pkg/in"
+	"aoc/pkg/render"
 	"aoc/pkg/vec"
 )
 
 //go:embed *.txt
 var inputs embed.FS
 
+var record = flag.String("record", "", "write the patrol of part one as GIF, or as asciinema cast if it ends in .cast")
+
+var roomPalette = render.Palette{
+	'.': render.White,
+	'#': render.Black,
+	'X': render.Yellow,
+	'^': render.Red,
+	'>': render.Red,
+	'v': render.Red,
+	'<': render.Red,
+}
+
 func main() {
+	flag.Parse()
 	start := time.Now()
 	partOne()
 	elapsed := time.Since(start)
@@ -44,7 +60,8 @@ func partTwo() {
 }
 
 type loopKey struct {
-	pos, dir vec.Vec2i
+	pos vec.Vec2i
+	dir vec.Dir
 }
 
 func isLoop(room [][]byte, start vec.Vec2i, obstruction vec.Vec2i) bool {
@@ -59,7 +76,7 @@ func isLoop(room [][]byte, start vec.Vec2i, obstruction vec.Vec2i) bool {
 	visited := map[loopKey]struct{}{}
 
 	boundary := vec.AABB{From: vec.Vec2i{}, To: vec.Vec2i{len(room[0]) - 1, len(room) - 1}}
-	dir := vec.Vec2i{0, -1}
+	dir := vec.N
 
 	pos := start
 	for {
@@ -69,15 +86,15 @@ func isLoop(room [][]byte, start vec.Vec2i, obstruction vec.Vec2i) bool {
 		}
 
 		visited[loopKey{pos, dir}] = struct{}{}
-		next := pos.Add(dir)
+		next := pos.Move(dir)
 		if !boundary.Contains(next) {
 			return false
 		}
 		for room[next.Y][next.X] == '#' || next == obstruction {
-			dir = vec.NewRotCW().Mul(dir)
-			next = pos.Add(dir)
+			dir = dir.TurnRight()
+			next = pos.Move(dir)
 		}
-		pos = pos.Add(dir)
+		pos = pos.Move(dir)
 	}
 }
 
@@ -87,12 +104,19 @@ func partOne() {
 
 	room := readRoom(file)
 
-	sum := walk(room)
+	var rec *render.Recorder
+	if *record != "" {
+		rec = render.NewRecorder(roomPalette, 4)
+	}
+	sum := walk(room, rec)
+	if err := rec.WriteFile(*record); err != nil {
+		log.Fatal(err)
+	}
 
 	fmt.Printf("part one: %d\n", sum)
 }
 
-func walk(room [][]byte) int {
+func walk(room [][]byte, rec *render.Recorder) int {
 	pos := findStart(room)
 
 	freq := make([][]byte, len(room))
@@ -101,17 +125,18 @@ func walk(room [][]byte) int {
 	}
 
 	boundary := vec.AABB{From: vec.Vec2i{}, To: vec.Vec2i{len(room[0]) - 1, len(room) - 1}}
-	dir := vec.Vec2i{0, -1}
+	dir := vec.N
 
 	for {
 		freq[pos.Y][pos.X]++
-		next := pos.Add(dir)
+		rec.Record(func() string { return patrolFrame(room, freq, pos, dir) })
+		next := pos.Move(dir)
 		if !boundary.Contains(next) {
 			break
 		}
 		if room[next.Y][next.X] == '#' {
-			dir = vec.NewRotCW().Mul(dir)
-			pos = pos.Add(dir)
+			dir = dir.TurnRight()
+			pos = pos.Move(dir)
 		} else {
 			pos = next
 		}
@@ -128,6 +153,28 @@ func walk(room [][]byte) int {
 	return sum
 }
 
+var guardRunes = map[vec.Dir]byte{vec.N: '^', vec.E: '>', vec.S: 'v', vec.W: '<'}
+
+// patrolFrame returns the room with the visited positions and the guard.
+func patrolFrame(room, freq [][]byte, pos vec.Vec2i, dir vec.Dir) string {
+	frame := make([][]byte, len(room))
+	for y, row := range room {
+		frame[y] = make([]byte, len(row))
+		for x, e := range row {
+			switch {
+			case freq[y][x] > 0:
+				frame[y][x] = 'X'
+			case e == '^':
+				frame[y][x] = '.'
+			default:
+				frame[y][x] = e
+			}
+		}
+	}
+	frame[pos.Y][pos.X] = guardRunes[dir]
+	return render.JoinLines(frame)
+}
+
 func readRoom(r io.Reader) [][]byte {
 	scanner := bufio.NewScanner(r)
 
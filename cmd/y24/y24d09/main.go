@@ -8,7 +8,6 @@ import (
 	"strings"
 	"time"
 
-	"aoc/pkg/lists"
 	"aoc/pkg/util"
 
 	"aoc/pkg/in"
@@ -113,26 +112,26 @@ func readDisk(r io.Reader) []*block {
 	str = strings.TrimSpace(str)
 
 	id := 0
-	blockRanges := lists.New[*block]()
+	var blocks []*block
 	for i, rn := range str {
 
 		num := int(byte(rn) - '0')
 
 		if i%2 == 0 {
 			// file
-			blockRanges.Append(&block{
+			blocks = append(blocks, &block{
 				fid:  id,
 				size: num,
 			})
 
 			id++
 		} else {
-			blockRanges.Append(&block{
+			blocks = append(blocks, &block{
 				fid:  -1,
 				size: num,
 			})
 		}
 	}
 
-	return blockRanges.ToSlice()
+	return blocks
 }
@@ -3,16 +3,27 @@ package main
 import (
 	"bufio"
 	"embed"
+	"flag"
 	"fmt"
+	"log"
 	"time"
 
 	"aoc/pkg/in"
+	"aoc/pkg/render"
 )
 
 //go:embed *.txt
 var inputs embed.FS
 
+var record = flag.String("record", "", "write the removal rounds of part two as GIF, or as asciinema cast if it ends in .cast")
+
+var rollPalette = render.Palette{
+	'.': render.White,
+	'@': render.Gray,
+}
+
 func main() {
+	flag.Parse()
 	start := time.Now()
 	partOne()
 	partTwo()
@@ -24,9 +35,16 @@ func partTwo() {
 	field := readInput()
 	newField := copyField(field)
 
+	var rec *render.Recorder
+	if *record != "" {
+		rec = render.NewRecorder(rollPalette, 4)
+		rec.Delay = 20
+	}
+
 	sum := 0
 
 	for {
+		rec.Record(func() string { return render.JoinLines(field) })
 		removed := 0
 		for i, row := range field {
 			for j, e := range row {
@@ -64,6 +82,9 @@ func partTwo() {
 		field = newField
 		newField = copyField(field)
 	}
+	if err := rec.WriteFile(*record); err != nil {
+		log.Fatal(err)
+	}
 	fmt.Printf("part two: %d\n", sum)
 }
 
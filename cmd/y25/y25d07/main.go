@@ -6,7 +6,10 @@ import (
 	"fmt"
 	"time"
 
+	"aoc/pkg/graph"
 	"aoc/pkg/in"
+	"aoc/pkg/util"
+	"aoc/pkg/vec"
 )
 
 //go:embed *.txt
@@ -20,67 +23,62 @@ func main() {
 	fmt.Printf("executed in: %s\n", elapsed)
 }
 
-type manifold struct {
-	next []*manifold
-}
-
 func partTwo() {
 	file := in.MustOpenInputTxt(inputs)
 	defer file.Close()
 
 	scanner := bufio.NewScanner(file)
 
-	var manifolds [][]*manifold
+	// every splitter is a node, beams leaving the manifold end in an exit node
+	manifolds := graph.NewDirected[vec.Vec2i]()
 
-	start := &manifold{}
+	var start vec.Vec2i
+	var beams [][]vec.Vec2i
 
+	y := 0
 	for scanner.Scan() {
 		line := scanner.Bytes()
-		if manifolds == nil {
-			manifolds = make([][]*manifold, len(line))
+		if beams == nil {
+			beams = make([][]vec.Vec2i, len(line))
 			for i, c := range line {
 				if c == 'S' {
-					manifolds[i] = append(manifolds[i], start)
+					start = vec.Vec2i{X: i, Y: y}
+					manifolds.AddNode(start)
+					beams[i] = append(beams[i], start)
 				}
 			}
+			y++
 			continue
 		}
 
 		for i, b := range line {
-			if b == '^' && manifolds[i] != nil {
-				prev := manifolds[i]
-				manifolds[i] = nil
-
-				next := &manifold{}
-				for _, p := range prev {
-					p.next = append(p.next, next)
+			if b == '^' && beams[i] != nil {
+				next := vec.Vec2i{X: i, Y: y}
+				for _, p := range beams[i] {
+					manifolds.AddEdge(p, next)
 				}
-				manifolds[i-1] = append(manifolds[i-1], next)
-				manifolds[i+1] = append(manifolds[i+1], next)
+				beams[i] = nil
+
+				beams[i-1] = append(beams[i-1], next)
+				beams[i+1] = append(beams[i+1], next)
 			}
 		}
+		y++
 	}
 
 	if err := scanner.Err(); err != nil {
 		panic(err)
 	}
 
-	memo := make(map[*manifold]int)
-	fmt.Printf("part two: %d\n", countPaths(start, memo)+1)
-}
-
-func countPaths(start *manifold, memo map[*manifold]int) int {
-	if cached, ok := memo[start]; ok {
-		return cached
-	}
-
-	sum := 0
-	for _, m := range start.next {
-		sum += countPaths(m, memo) + 1
+	for i, sources := range beams {
+		exit := vec.Vec2i{X: i, Y: y}
+		for _, p := range sources {
+			manifolds.AddEdge(p, exit)
+		}
 	}
 
-	memo[start] = sum
-	return sum
+	timelines := util.Must(manifolds.CountPathsToSinks(start))
+	fmt.Printf("part two: %d\n", timelines)
 }
 
 func partOne() {
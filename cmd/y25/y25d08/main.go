@@ -10,6 +10,8 @@ import (
 	"strings"
 	"time"
 
+	"aoc/pkg/mst"
+	"aoc/pkg/util"
 	"aoc/pkg/vec"
 
 	"aoc/pkg/in"
@@ -27,188 +29,30 @@ func main() {
 }
 
 func partTwo() {
-	positions := readJunctions()
-
-	var junctions []*junction
-	for _, p := range positions {
-		j := &junction{pos: p}
-		junctions = append(junctions, j)
-	}
-
-	var edges []*edge
-	for i, a := range junctions {
-		for j := i + 1; j < len(junctions); j++ {
-			n := junctions[j]
-			e := &edge{
-				distance: a.pos.Sub(n.pos).Abs(),
-				from:     a,
-				to:       n,
-			}
-			edges = append(edges, e)
-		}
-	}
-
-	slices.SortFunc(edges, func(a, b *edge) int {
-		return cmp.Compare(a.distance, b.distance)
-	})
+	junctions := readJunctions()
 
 	// wire them up
-	circuitId := 1
-	sum := 0
-	for _, e := range edges {
-
-		if e.to.circuit != nil && e.from.circuit != nil {
-			_, ok := e.to.circuit.nodes[e.from.pos]
-			if ok {
-				continue
-			}
-		}
-
-		if e.to.circuit == nil && e.from.circuit == nil {
-			// none is in a circuit yet
-			nc := make(map[vec.Vec3i]*junction)
-			nc[e.from.pos] = e.from
-			nc[e.to.pos] = e.to
-
-			c := &circuit{id: circuitId, nodes: nc}
-			circuitId++
-
-			e.from.circuit = c
-			e.to.circuit = c
-		} else if e.to.circuit == nil {
-			// 'from' is in a circuit
-			e.from.circuit.nodes[e.to.pos] = e.to
-			e.to.circuit = e.from.circuit
-			if len(e.from.circuit.nodes) == len(junctions) {
-				sum = e.from.pos.X * e.to.pos.X
-				break
-			}
-		} else if e.from.circuit == nil {
-			// 'to' is in a circuit
-			e.to.circuit.nodes[e.from.pos] = e.from
-			e.from.circuit = e.to.circuit
-			if len(e.to.circuit.nodes) == len(junctions) {
-				sum = e.from.pos.X * e.to.pos.X
-				break
-			}
-		} else {
-			// merge two circuits
-			first := e.from.circuit
-			for k, v := range e.to.circuit.nodes {
-				first.nodes[k] = v
-				v.circuit = first
-			}
-
-			if len(first.nodes) == len(junctions) {
-				sum = e.from.pos.X * e.to.pos.X
-				break
-			}
-		}
-	}
+	circuits := mst.NewConnector(len(junctions), mst.AllPairs(junctions, mst.SquaredEuclidean3i))
+	last := util.MustOk(circuits.ConnectUntil(1))
+	sum := junctions[last.A].X * junctions[last.B].X
 
 	// 3767453340
 	fmt.Printf("part two: %d\n", sum)
 }
 
-type junction struct {
-	pos     vec.Vec3i
-	circuit *circuit
-}
-
-type edge struct {
-	distance float32
-	from     *junction
-	to       *junction
-}
-
-type circuit struct {
-	id    int
-	nodes map[vec.Vec3i]*junction
-}
-
 func partOne() {
-	positions := readJunctions()
-
-	var junctions []*junction
-	for _, p := range positions {
-		j := &junction{pos: p}
-		junctions = append(junctions, j)
-	}
-
-	var edges []*edge
-	for i, a := range junctions {
-		for j := i + 1; j < len(junctions); j++ {
-			n := junctions[j]
-			e := &edge{
-				distance: a.pos.Sub(n.pos).Abs(),
-				from:     a,
-				to:       n,
-			}
-			edges = append(edges, e)
-		}
-	}
-
-	slices.SortFunc(edges, func(a, b *edge) int {
-		return cmp.Compare(a.distance, b.distance)
-	})
+	junctions := readJunctions()
 
 	// wire them up
 	cables := 1000
-	circuitId := 1
-	for _, e := range edges {
-		if cables <= 0 {
-			break
-		}
-		cables--
-
-		if e.to.circuit != nil && e.from.circuit != nil {
-			_, ok := e.to.circuit.nodes[e.from.pos]
-			if ok {
-				continue
-			}
-		}
-
-		if e.to.circuit == nil && e.from.circuit == nil {
-			// none is in a circuit yet
-			nc := make(map[vec.Vec3i]*junction)
-			nc[e.from.pos] = e.from
-			nc[e.to.pos] = e.to
-
-			c := &circuit{id: circuitId, nodes: nc}
-			circuitId++
-
-			e.from.circuit = c
-			e.to.circuit = c
-		} else if e.to.circuit == nil {
-			// 'from' is in a circuit
-			e.from.circuit.nodes[e.to.pos] = e.to
-			e.to.circuit = e.from.circuit
-		} else if e.from.circuit == nil {
-			// 'to' is in a circuit
-			e.to.circuit.nodes[e.from.pos] = e.from
-			e.from.circuit = e.to.circuit
-		} else {
-			// merge two circuits
-			first := e.from.circuit
-			for k, v := range e.to.circuit.nodes {
-				first.nodes[k] = v
-				v.circuit = first
-			}
-		}
-	}
+	circuits := mst.NewConnector(len(junctions), mst.AllPairs(junctions, mst.SquaredEuclidean3i))
+	circuits.Connect(cables)
 
-	circuitMap := map[int]int{}
-	for _, j := range junctions {
-		if j.circuit == nil {
-			continue
+	lengths := circuits.ComponentSizes()
+	for k, v := range lengths {
+		if v > 1 {
+			fmt.Printf("circuit %d: %d nodes\n", k, v)
 		}
-		circuitMap[j.circuit.id] = len(j.circuit.nodes)
-	}
-
-	var lengths []int
-	for k, v := range circuitMap {
-		lengths = append(lengths, v)
-		fmt.Printf("circuit %d: %d nodes\n", k, v)
 	}
 
 	slices.Sort(lengths)
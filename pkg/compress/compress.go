@@ -0,0 +1,192 @@
+// Package compress maps sparse, huge coordinates onto a small dense grid
+// while keeping track of how much real space each compressed cell stands for.
+package compress
+
+import (
+	"slices"
+	"sort"
+
+	"aoc/pkg/vec"
+)
+
+// Axis maps a sorted set of interesting coordinates onto consecutive
+// indices. Index i covers the real coordinates from its value up to, but
+// excluding, the value of index i+1. The last index covers its value only.
+type Axis struct {
+	values []int
+}
+
+// NewAxis creates an axis of the given values and their pad neighbours on
+// either side. With pad 1 every value gets a cell of width one, separated
+// from the next value by a cell for the gap in between.
+func NewAxis(values []int, pad int) Axis {
+	all := make([]int, 0, len(values)*(2*pad+1))
+	for _, v := range values {
+		for d := -pad; d <= pad; d++ {
+			all = append(all, v+d)
+		}
+	}
+	slices.Sort(all)
+	return Axis{values: slices.Compact(all)}
+}
+
+func (a Axis) Len() int {
+	return len(a.values)
+}
+
+// Values returns the sorted coordinates, the result must not be modified.
+func (a Axis) Values() []int {
+	return a.values
+}
+
+// Value returns the real coordinate at which cell i starts.
+func (a Axis) Value(i int) int {
+	return a.values[i]
+}
+
+// Index returns the index of v, false if v is not one of the values.
+func (a Axis) Index(v int) (int, bool) {
+	return slices.BinarySearch(a.values, v)
+}
+
+// Cell returns the index of the cell covering v, false if v lies outside of
+// the axis.
+func (a Axis) Cell(v int) (int, bool) {
+	if len(a.values) == 0 || v < a.values[0] || v > a.values[len(a.values)-1] {
+		return 0, false
+	}
+	i := sort.SearchInts(a.values, v+1) - 1
+	return i, true
+}
+
+// Width returns the number of real coordinates covered by cell i.
+func (a Axis) Width(i int) int {
+	if i == len(a.values)-1 {
+		return 1
+	}
+	return a.values[i+1] - a.values[i]
+}
+
+// Grid is a compressed 2D grid made of an axis in each dimension.
+type Grid struct {
+	X, Y Axis
+}
+
+// New creates a grid of the coordinates of points, see NewAxis for pad.
+func New(points []vec.Vec2i, pad int) Grid {
+	xs := make([]int, len(points))
+	ys := make([]int, len(points))
+	for i, p := range points {
+		xs[i], ys[i] = p.X, p.Y
+	}
+	return Grid{X: NewAxis(xs, pad), Y: NewAxis(ys, pad)}
+}
+
+// NewFromBoxes creates a grid where each box maps exactly onto a rectangle
+// of cells, so that Fill doesn't spill over into neighbouring space.
+func NewFromBoxes(boxes []vec.AABB) Grid {
+	points := make([]vec.Vec2i, 0, 2*len(boxes))
+	for _, b := range boxes {
+		points = append(points, b.From, b.To.Add(vec.Vec2i{X: 1, Y: 1}))
+	}
+	return New(points, 0)
+}
+
+// Size returns the number of compressed cells in each dimension.
+func (g Grid) Size() vec.Vec2i {
+	return vec.Vec2i{X: g.X.Len(), Y: g.Y.Len()}
+}
+
+// Bounds returns the box of compressed cell indices.
+func (g Grid) Bounds() vec.AABB {
+	return vec.NewAABBFromSize(vec.Vec2i{}, g.Size())
+}
+
+// RealBounds returns the box of real coordinates covered by the grid.
+func (g Grid) RealBounds() vec.AABB {
+	if g.X.Len() == 0 || g.Y.Len() == 0 {
+		return vec.AABB{From: vec.Vec2i{X: 1, Y: 1}}
+	}
+	return vec.BoundingBox2i([]vec.Vec2i{
+		g.Expand(vec.Vec2i{}),
+		g.Expand(g.Size().Sub(vec.Vec2i{X: 1, Y: 1})),
+	})
+}
+
+// Compress returns the cell index of p, false if either coordinate is not
+// one of the values of its axis.
+func (g Grid) Compress(p vec.Vec2i) (vec.Vec2i, bool) {
+	x, okX := g.X.Index(p.X)
+	y, okY := g.Y.Index(p.Y)
+	return vec.Vec2i{X: x, Y: y}, okX && okY
+}
+
+// Cell returns the index of the cell covering p, false if p lies outside of
+// the grid.
+func (g Grid) Cell(p vec.Vec2i) (vec.Vec2i, bool) {
+	x, okX := g.X.Cell(p.X)
+	y, okY := g.Y.Cell(p.Y)
+	return vec.Vec2i{X: x, Y: y}, okX && okY
+}
+
+// Expand returns the real coordinate at which cell c starts.
+func (g Grid) Expand(c vec.Vec2i) vec.Vec2i {
+	return vec.Vec2i{X: g.X.Value(c.X), Y: g.Y.Value(c.Y)}
+}
+
+// CellBox returns the box of real coordinates covered by cell c.
+func (g Grid) CellBox(c vec.Vec2i) vec.AABB {
+	size := vec.Vec2i{X: g.X.Width(c.X), Y: g.Y.Width(c.Y)}
+	return vec.NewAABBFromSize(g.Expand(c), size)
+}
+
+// CellArea returns the number of real points covered by cell c.
+func (g Grid) CellArea(c vec.Vec2i) int {
+	return g.X.Width(c.X) * g.Y.Width(c.Y)
+}
+
+// Map stores a value for each cell of a compressed grid.
+type Map[T any] struct {
+	Grid
+	Cells [][]T // indexed [y][x]
+}
+
+func NewMap[T any](g Grid) *Map[T] {
+	cells := make([][]T, g.Y.Len())
+	for y := range cells {
+		cells[y] = make([]T, g.X.Len())
+	}
+	return &Map[T]{Grid: g, Cells: cells}
+}
+
+func (m *Map[T]) At(c vec.Vec2i) T {
+	return m.Cells[c.Y][c.X]
+}
+
+func (m *Map[T]) Set(c vec.Vec2i, v T) {
+	m.Cells[c.Y][c.X] = v
+}
+
+// Fill sets all cells overlapping the real box to v, clipped to the grid.
+func (m *Map[T]) Fill(box vec.AABB, v T) {
+	box = box.Intersect(m.RealBounds())
+	if box.IsEmpty() {
+		return
+	}
+	from, _ := m.Cell(box.From)
+	to, _ := m.Cell(box.To)
+	vec.AABB{From: from, To: to}.ForEach(func(c vec.Vec2i) {
+		m.Set(c, v)
+	})
+}
+
+// Area returns the number of real points covered by cells matching keep.
+func (m *Map[T]) Area(keep func(T) bool) int {
+	sum := 0
+	m.Bounds().ForEach(func(c vec.Vec2i) {
+		if keep(m.At(c)) {
+			sum += m.CellArea(c)
+		}
+	})
+	return sum
+}
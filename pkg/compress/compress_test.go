@@ -0,0 +1,76 @@
+package compress
+
+import (
+	"testing"
+
+	"aoc/pkg/be"
+	"aoc/pkg/vec"
+)
+
+func TestAxis(t *testing.T) {
+	a := NewAxis([]int{100, 5, 5, 20}, 0)
+
+	be.Equal(t, a.Len(), 3)
+	be.Equal(t, a.Value(1), 20)
+	be.Equal(t, a.Width(0), 15)
+	be.Equal(t, a.Width(2), 1)
+
+	i, ok := a.Index(20)
+	be.True(t, ok)
+	be.Equal(t, i, 1)
+	_, ok = a.Index(21)
+	be.True(t, !ok)
+
+	i, ok = a.Cell(99)
+	be.True(t, ok)
+	be.Equal(t, i, 1)
+	_, ok = a.Cell(101)
+	be.True(t, !ok)
+}
+
+func TestAxis_Pad(t *testing.T) {
+	a := NewAxis([]int{0, 10}, 1)
+
+	be.Equal(t, a.Len(), 6)
+	be.Equal(t, a.Width(1), 1)
+	be.Equal(t, a.Width(2), 8)
+}
+
+func TestGrid(t *testing.T) {
+	g := New([]vec.Vec2i{{X: 0, Y: 0}, {X: 1000, Y: 10}, {X: 10, Y: 4000000}}, 0)
+
+	be.Equal(t, g.Size(), vec.Vec2i{X: 3, Y: 3})
+	be.Equal(t, g.RealBounds(), vec.AABB{To: vec.Vec2i{X: 1000, Y: 4000000}})
+
+	c, ok := g.Compress(vec.Vec2i{X: 1000, Y: 10})
+	be.True(t, ok)
+	be.Equal(t, c, vec.Vec2i{X: 2, Y: 1})
+	be.Equal(t, g.Expand(c), vec.Vec2i{X: 1000, Y: 10})
+
+	c, ok = g.Cell(vec.Vec2i{X: 5, Y: 5})
+	be.True(t, ok)
+	be.Equal(t, c, vec.Vec2i{})
+	be.Equal(t, g.CellBox(c), vec.AABB{To: vec.Vec2i{X: 9, Y: 9}})
+	be.Equal(t, g.CellArea(c), 100)
+
+	sum := 0
+	g.Bounds().ForEach(func(c vec.Vec2i) {
+		sum += g.CellArea(c)
+	})
+	be.Equal(t, sum, g.RealBounds().Area())
+}
+
+func TestMap_Fill(t *testing.T) {
+	boxes := []vec.AABB{
+		{From: vec.Vec2i{X: 0, Y: 0}, To: vec.Vec2i{X: 999, Y: 999}},
+		{From: vec.Vec2i{X: 500, Y: 500}, To: vec.Vec2i{X: 1499, Y: 1499}},
+		{From: vec.Vec2i{X: 5000, Y: -20}, To: vec.Vec2i{X: 5000, Y: 20}},
+	}
+	m := NewMap[bool](NewFromBoxes(boxes))
+	for _, b := range boxes {
+		m.Fill(b, true)
+	}
+
+	filled := m.Area(func(b bool) bool { return b })
+	be.Equal(t, filled, 2*1000*1000-500*500+41)
+}
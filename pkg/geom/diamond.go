@@ -0,0 +1,194 @@
+package geom
+
+import (
+	"cmp"
+	"slices"
+
+	"aoc/pkg/sets"
+	"aoc/pkg/vec"
+)
+
+// Diamond is the ball of all points within a manhattan distance of Radius
+// around Center.
+type Diamond struct {
+	Center vec.Vec2i
+	Radius int
+}
+
+func (d Diamond) Contains(p vec.Vec2i) bool {
+	return d.Center.Manhattan(p) <= d.Radius
+}
+
+// Grow returns a diamond around the same center with a radius larger by n.
+func (d Diamond) Grow(n int) Diamond {
+	return Diamond{Center: d.Center, Radius: d.Radius + n}
+}
+
+// Row returns the span of the diamond on row y, false if it doesn't reach
+// that row.
+func (d Diamond) Row(y int) (Interval, bool) {
+	w := d.Radius - vec.Abs(y-d.Center.Y)
+	if w < 0 {
+		return Interval{}, false
+	}
+	return Interval{From: d.Center.X - w, To: d.Center.X + w}, true
+}
+
+// Boundary returns all points at exactly Radius distance, clockwise
+// starting at the top.
+func (d Diamond) Boundary() []vec.Vec2i {
+	if d.Radius == 0 {
+		return []vec.Vec2i{d.Center}
+	}
+
+	points := make([]vec.Vec2i, 0, 4*d.Radius)
+	corners := []vec.Vec2i{
+		{X: 0, Y: -d.Radius},
+		{X: d.Radius, Y: 0},
+		{X: 0, Y: d.Radius},
+		{X: -d.Radius, Y: 0},
+	}
+	for i, corner := range corners {
+		step := corners[(i+1)%4].Sub(corner).Sign()
+		p := d.Center.Add(corner)
+		for j := 0; j < d.Radius; j++ {
+			points = append(points, p)
+			p = p.Add(step)
+		}
+	}
+	return points
+}
+
+// Rotated returns the diamond in rotated coordinates, where it becomes an
+// axis aligned box, see Rotate45.
+func (d Diamond) Rotated() vec.AABB {
+	c := Rotate45(d.Center)
+	r := vec.Vec2i{X: d.Radius, Y: d.Radius}
+	return vec.AABB{From: c.Sub(r), To: c.Add(r)}
+}
+
+// Rotate45 maps p onto the rotated coordinates u = x+y, v = x-y. Manhattan
+// distances turn into Chebyshev distances, diamonds into squares.
+func Rotate45(p vec.Vec2i) vec.Vec2i {
+	return vec.Vec2i{X: p.X + p.Y, Y: p.X - p.Y}
+}
+
+// Unrotate45 maps rotated coordinates back, false if they don't correspond
+// to a lattice point, i.e. u and v have different parity.
+func Unrotate45(uv vec.Vec2i) (vec.Vec2i, bool) {
+	if (uv.X-uv.Y)%2 != 0 {
+		return vec.Vec2i{}, false
+	}
+	return vec.Vec2i{X: (uv.X + uv.Y) / 2, Y: (uv.X - uv.Y) / 2}, true
+}
+
+// Interval is a range of integers, both ends inclusive.
+type Interval struct{ From, To int }
+
+func (i Interval) Len() int {
+	return max(0, i.To-i.From+1)
+}
+
+// MergeIntervals returns the union of the intervals as sorted, disjoint and
+// non-adjacent intervals.
+func MergeIntervals(intervals []Interval) []Interval {
+	sorted := slices.Clone(intervals)
+	slices.SortFunc(sorted, func(a, b Interval) int {
+		return cmp.Compare(a.From, b.From)
+	})
+
+	var merged []Interval
+	for _, i := range sorted {
+		if i.Len() == 0 {
+			continue
+		}
+		if len(merged) > 0 && i.From <= merged[len(merged)-1].To+1 {
+			last := &merged[len(merged)-1]
+			last.To = max(last.To, i.To)
+			continue
+		}
+		merged = append(merged, i)
+	}
+	return merged
+}
+
+// RowCoverage returns the spans of row y covered by any of the diamonds.
+func RowCoverage(diamonds []Diamond, y int) []Interval {
+	var spans []Interval
+	for _, d := range diamonds {
+		if span, ok := d.Row(y); ok {
+			spans = append(spans, span)
+		}
+	}
+	return MergeIntervals(spans)
+}
+
+// CountCovered returns the number of points on row y covered by any of the
+// diamonds.
+func CountCovered(diamonds []Diamond, y int) int {
+	sum := 0
+	for _, span := range RowCoverage(diamonds, y) {
+		sum += span.Len()
+	}
+	return sum
+}
+
+// Uncovered returns the points within box not covered by any diamond that
+// lie where the lines just outside the diamonds cross each other or the
+// edges of box, including its corners. An uncovered point whose neighbours
+// are all covered or outside box is always one of them, larger uncovered
+// areas are only reported by some of their points. Instead of scanning the
+// box it only intersects the outlines in rotated coordinates.
+func Uncovered(diamonds []Diamond, box vec.AABB) []vec.Vec2i {
+	// the lines just outside the diamonds, u = x+y and v = x-y
+	us := sets.New[int]()
+	vs := sets.New[int]()
+	for _, d := range diamonds {
+		c := Rotate45(d.Center)
+		us.PutAll([]int{c.X - d.Radius - 1, c.X + d.Radius + 1})
+		vs.PutAll([]int{c.Y - d.Radius - 1, c.Y + d.Radius + 1})
+	}
+
+	candidates := []vec.Vec2i{
+		box.From, box.To,
+		{X: box.From.X, Y: box.To.Y},
+		{X: box.To.X, Y: box.From.Y},
+	}
+	for u := range us {
+		for v := range vs {
+			if p, ok := Unrotate45(vec.Vec2i{X: u, Y: v}); ok {
+				candidates = append(candidates, p)
+			}
+		}
+	}
+	for _, x := range []int{box.From.X, box.To.X} {
+		for u := range us {
+			candidates = append(candidates, vec.Vec2i{X: x, Y: u - x})
+		}
+		for v := range vs {
+			candidates = append(candidates, vec.Vec2i{X: x, Y: x - v})
+		}
+	}
+	for _, y := range []int{box.From.Y, box.To.Y} {
+		for u := range us {
+			candidates = append(candidates, vec.Vec2i{X: u - y, Y: y})
+		}
+		for v := range vs {
+			candidates = append(candidates, vec.Vec2i{X: v + y, Y: y})
+		}
+	}
+
+	found := sets.New[vec.Vec2i]()
+	for _, p := range candidates {
+		if !box.Contains(p) {
+			continue
+		}
+		covered := slices.ContainsFunc(diamonds, func(d Diamond) bool {
+			return d.Contains(p)
+		})
+		if !covered {
+			found.Put(p)
+		}
+	}
+	return found.SortedKeys(vec.Compare2i)
+}
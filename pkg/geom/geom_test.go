@@ -0,0 +1,153 @@
+package geom
+
+import (
+	"slices"
+	"testing"
+
+	"aoc/pkg/be"
+	"aoc/pkg/vec"
+)
+
+func TestPolygon_Square(t *testing.T) {
+	// 4x4 square with its corners at 0 and 4
+	p := NewPolygon([]vec.Vec2i{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4}, {X: 0, Y: 4}, {X: 0, Y: 0}})
+
+	be.Equal(t, len(p.Vertices), 4)
+	be.Equal(t, p.Area(), 16)
+	be.Equal(t, p.Perimeter(), 16.0)
+	be.Equal(t, p.BoundaryPoints(), 16)
+	be.Equal(t, p.InteriorPoints(), 9)
+	be.Equal(t, p.LatticePoints(), 25)
+
+	be.True(t, p.Contains(vec.Vec2i{X: 1, Y: 1}))
+	be.True(t, !p.Contains(vec.Vec2i{X: 0, Y: 2}))
+	be.True(t, p.OnBoundary(vec.Vec2i{X: 0, Y: 2}))
+	be.True(t, !p.Contains(vec.Vec2i{X: 5, Y: 2}))
+}
+
+func TestPolygon_FromMoves(t *testing.T) {
+	// example dig plan of 2023 day 18
+	moves := []Move{
+		{vec.E, 6}, {vec.S, 5}, {vec.W, 2}, {vec.S, 2}, {vec.E, 2}, {vec.S, 2}, {vec.W, 5},
+		{vec.N, 2}, {vec.W, 1}, {vec.N, 2}, {vec.E, 2}, {vec.N, 3}, {vec.W, 2}, {vec.N, 2},
+	}
+
+	p := NewPolygonFromMoves(vec.Vec2i{}, moves)
+	be.Equal(t, p.LatticePoints(), 62)
+}
+
+func TestPolygon_Concave(t *testing.T) {
+	// U shape, the notch at the top is outside
+	p := NewPolygon([]vec.Vec2i{
+		{X: 0, Y: 0}, {X: 6, Y: 0}, {X: 6, Y: 6}, {X: 4, Y: 6},
+		{X: 4, Y: 2}, {X: 2, Y: 2}, {X: 2, Y: 6}, {X: 0, Y: 6},
+	})
+
+	be.Equal(t, p.Area(), 28)
+	be.True(t, p.Contains(vec.Vec2i{X: 1, Y: 5}))
+	be.True(t, p.Contains(vec.Vec2i{X: 3, Y: 1}))
+	be.True(t, !p.Contains(vec.Vec2i{X: 3, Y: 4}))
+	be.True(t, !p.Contains(vec.Vec2i{X: 3, Y: 6}))
+
+	// count interior points by brute force
+	count := 0
+	p.BoundingBox().ForEach(func(q vec.Vec2i) {
+		if p.Contains(q) {
+			count++
+		}
+	})
+	be.Equal(t, count, p.InteriorPoints())
+}
+
+func TestPolygon_HugeCoordinates(t *testing.T) {
+	p := NewPolygonFromMoves(vec.Vec2i{}, []Move{
+		{vec.E, 4_000_000}, {vec.S, 3_000_000}, {vec.W, 4_000_000}, {vec.N, 3_000_000},
+	})
+
+	be.Equal(t, p.Area(), 12_000_000_000_000)
+	be.Equal(t, p.LatticePoints(), 4_000_001*3_000_001)
+}
+
+func TestSegment_Intersects(t *testing.T) {
+	s := Segment{From: vec.Vec2i{X: 0, Y: 0}, To: vec.Vec2i{X: 4, Y: 4}}
+
+	be.True(t, s.Intersects(Segment{From: vec.Vec2i{X: 0, Y: 4}, To: vec.Vec2i{X: 4, Y: 0}}))
+	be.True(t, s.Intersects(Segment{From: vec.Vec2i{X: 4, Y: 4}, To: vec.Vec2i{X: 5, Y: 0}}))
+	be.True(t, s.Intersects(Segment{From: vec.Vec2i{X: 2, Y: 2}, To: vec.Vec2i{X: 6, Y: 6}}))
+	be.True(t, !s.Intersects(Segment{From: vec.Vec2i{X: 5, Y: 5}, To: vec.Vec2i{X: 6, Y: 6}}))
+	be.True(t, !s.Intersects(Segment{From: vec.Vec2i{X: 1, Y: 0}, To: vec.Vec2i{X: 4, Y: 3}}))
+
+	be.Equal(t, s.LatticePoints(), 4)
+}
+
+func TestDiamond(t *testing.T) {
+	d := Diamond{Center: vec.Vec2i{X: 8, Y: 7}, Radius: 9}
+
+	row, ok := d.Row(10)
+	be.True(t, ok)
+	be.Equal(t, row, Interval{From: 2, To: 14})
+
+	_, ok = d.Row(17)
+	be.True(t, !ok)
+
+	boundary := d.Boundary()
+	be.Equal(t, len(boundary), 36)
+	for _, p := range boundary {
+		be.Equal(t, d.Center.Manhattan(p), d.Radius)
+	}
+
+	p := vec.Vec2i{X: 3, Y: -2}
+	back, ok := Unrotate45(Rotate45(p))
+	be.True(t, ok)
+	be.Equal(t, back, p)
+	be.True(t, d.Rotated().Contains(Rotate45(vec.Vec2i{X: 8, Y: -2})))
+}
+
+func TestMergeIntervals(t *testing.T) {
+	merged := MergeIntervals([]Interval{{5, 7}, {0, 2}, {3, 3}, {10, 12}, {11, 11}})
+
+	be.Equal(t, len(merged), 3)
+	be.Equal(t, merged[0], Interval{From: 0, To: 3})
+	be.Equal(t, merged[1], Interval{From: 5, To: 7})
+	be.Equal(t, merged[2], Interval{From: 10, To: 12})
+}
+
+// example sensors of 2022 day 15 with their distances to the closest beacon
+var exampleSensors = []Diamond{
+	{vec.Vec2i{X: 2, Y: 18}, 7}, {vec.Vec2i{X: 9, Y: 16}, 1}, {vec.Vec2i{X: 13, Y: 2}, 3},
+	{vec.Vec2i{X: 12, Y: 14}, 4}, {vec.Vec2i{X: 10, Y: 20}, 4}, {vec.Vec2i{X: 14, Y: 17}, 5},
+	{vec.Vec2i{X: 8, Y: 7}, 9}, {vec.Vec2i{X: 2, Y: 0}, 10}, {vec.Vec2i{X: 0, Y: 11}, 3},
+	{vec.Vec2i{X: 20, Y: 14}, 8}, {vec.Vec2i{X: 17, Y: 20}, 6}, {vec.Vec2i{X: 16, Y: 7}, 5},
+	{vec.Vec2i{X: 14, Y: 3}, 1}, {vec.Vec2i{X: 20, Y: 1}, 7},
+}
+
+func TestCountCovered(t *testing.T) {
+	// one of the covered points is a beacon
+	be.Equal(t, CountCovered(exampleSensors, 10), 27)
+}
+
+func TestUncovered(t *testing.T) {
+	box := vec.AABB{To: vec.Vec2i{X: 20, Y: 20}}
+
+	uncovered := Uncovered(exampleSensors, box)
+	be.Equal(t, len(uncovered), 1)
+	be.Equal(t, uncovered[0], vec.Vec2i{X: 14, Y: 11})
+}
+
+func TestUncoveredOnEdge(t *testing.T) {
+	box := vec.AABB{To: vec.Vec2i{X: 6, Y: 6}}
+	diamonds := []Diamond{
+		{Center: vec.Vec2i{X: 3, Y: 3}, Radius: 3},
+		{Center: vec.Vec2i{X: 1, Y: -1}, Radius: 1},
+		{Center: vec.Vec2i{X: 0, Y: 1}, Radius: 1},
+	}
+
+	// (2, 0) is only bounded by the outline of the first diamond and the
+	// top edge of the box
+	uncovered := Uncovered(diamonds, box)
+	be.True(t, slices.Contains(uncovered, vec.Vec2i{X: 2, Y: 0}))
+	for _, p := range uncovered {
+		be.True(t, box.Contains(p))
+		be.True(t, !slices.ContainsFunc(diamonds, func(d Diamond) bool { return d.Contains(p) }))
+	}
+}
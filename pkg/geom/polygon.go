@@ -0,0 +1,153 @@
+package geom
+
+import (
+	"math"
+
+	"aoc/pkg/vec"
+)
+
+// Polygon is a simple polygon on integer coordinates, the last vertex
+// connects back to the first one.
+type Polygon struct {
+	Vertices []vec.Vec2i
+}
+
+// NewPolygon creates a polygon from a vertex loop. Consecutive duplicates and
+// a closing vertex equal to the first one are dropped, collinear vertices
+// such as every cell of a walked loop are fine.
+func NewPolygon(vertices []vec.Vec2i) *Polygon {
+	var loop []vec.Vec2i
+	for _, v := range vertices {
+		if len(loop) == 0 || loop[len(loop)-1] != v {
+			loop = append(loop, v)
+		}
+	}
+	if len(loop) > 1 && loop[0] == loop[len(loop)-1] {
+		loop = loop[:len(loop)-1]
+	}
+	return &Polygon{Vertices: loop}
+}
+
+// Move is a straight walk of Length steps into a direction, as found in
+// dig plans.
+type Move struct {
+	Dir    vec.Dir
+	Length int
+}
+
+// NewPolygonFromMoves traces the moves starting at start, the moves are
+// expected to end at start again.
+func NewPolygonFromMoves(start vec.Vec2i, moves []Move) *Polygon {
+	vertices := make([]vec.Vec2i, 0, len(moves)+1)
+	current := start
+	vertices = append(vertices, current)
+	for _, m := range moves {
+		current = current.Add(m.Dir.Vec().Scale(m.Length))
+		vertices = append(vertices, current)
+	}
+	return NewPolygon(vertices)
+}
+
+// Edges returns the segments along the boundary.
+func (p *Polygon) Edges() []Segment {
+	edges := make([]Segment, len(p.Vertices))
+	for i, v := range p.Vertices {
+		edges[i] = Segment{From: v, To: p.Vertices[(i+1)%len(p.Vertices)]}
+	}
+	return edges
+}
+
+// SignedDoubleArea returns twice the signed area using the shoelace formula.
+// It is positive for vertices in counterclockwise order with Y pointing up,
+// i.e. clockwise in screen coordinates.
+//
+// https://en.wikipedia.org/wiki/Shoelace_formula
+func (p *Polygon) SignedDoubleArea() int {
+	sum := 0
+	for i, v := range p.Vertices {
+		sum += v.PerpDot(p.Vertices[(i+1)%len(p.Vertices)])
+	}
+	return sum
+}
+
+// DoubleArea returns twice the area, which is always an integer.
+func (p *Polygon) DoubleArea() int {
+	return vec.Abs(p.SignedDoubleArea())
+}
+
+// Area returns the area, rounded down for polygons with half-integer area.
+func (p *Polygon) Area() int {
+	return p.DoubleArea() / 2
+}
+
+// Perimeter returns the euclidean length of the boundary.
+func (p *Polygon) Perimeter() float64 {
+	sum := 0.0
+	for _, e := range p.Edges() {
+		d := e.To.Sub(e.From)
+		sum += math.Hypot(float64(d.X), float64(d.Y))
+	}
+	return sum
+}
+
+// BoundaryPoints returns the number of lattice points on the boundary. For
+// rectilinear polygons this equals the perimeter.
+func (p *Polygon) BoundaryPoints() int {
+	sum := 0
+	for _, e := range p.Edges() {
+		sum += e.LatticePoints()
+	}
+	return sum
+}
+
+// InteriorPoints returns the number of lattice points strictly inside using
+// Pick's theorem, e.g. the tiles enclosed by a loop of pipes.
+//
+// https://en.wikipedia.org/wiki/Pick%27s_theorem
+func (p *Polygon) InteriorPoints() int {
+	// A = i + b/2 - 1
+	return (p.DoubleArea() - p.BoundaryPoints() + 2) / 2
+}
+
+// LatticePoints returns the number of lattice points inside or on the
+// boundary, e.g. the cubic meters of a dug out lagoon.
+func (p *Polygon) LatticePoints() int {
+	return p.InteriorPoints() + p.BoundaryPoints()
+}
+
+// OnBoundary reports whether q lies on any edge.
+func (p *Polygon) OnBoundary(q vec.Vec2i) bool {
+	for _, e := range p.Edges() {
+		if e.Contains(q) {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains reports whether q lies strictly inside the polygon, by casting a
+// ray into positive X direction and counting the edges it crosses.
+func (p *Polygon) Contains(q vec.Vec2i) bool {
+	if p.OnBoundary(q) {
+		return false
+	}
+
+	inside := false
+	for _, e := range p.Edges() {
+		a, b := e.From, e.To
+		// half-open rule, vertices on the ray are only counted once
+		if (a.Y > q.Y) == (b.Y > q.Y) {
+			continue
+		}
+		// does the edge cross the ray right of q?
+		o := orientation(a, b, q)
+		if (b.Y > a.Y) == (o > 0) {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+func (p *Polygon) BoundingBox() vec.AABB {
+	return vec.BoundingBox2i(p.Vertices)
+}
@@ -0,0 +1,60 @@
+package geom
+
+import (
+	"fmt"
+
+	"aoc/pkg/vec"
+)
+
+// Segment is a straight line between two lattice points, both ends included.
+type Segment struct{ From, To vec.Vec2i }
+
+// orientation is positive if c is counterclockwise of a->b, negative if
+// clockwise and zero if all three points are collinear.
+func orientation(a, b, c vec.Vec2i) int {
+	return b.Sub(a).PerpDot(c.Sub(a))
+}
+
+// Contains reports whether p lies on the segment.
+func (s Segment) Contains(p vec.Vec2i) bool {
+	if orientation(s.From, s.To, p) != 0 {
+		return false
+	}
+	box := vec.AABB{From: s.From.Min(s.To), To: s.From.Max(s.To)}
+	return box.Contains(p)
+}
+
+// Intersects reports whether both segments share at least one point,
+// touching ends and collinear overlaps count.
+//
+// https://en.wikipedia.org/wiki/Line_segment_intersection
+func (s Segment) Intersects(o Segment) bool {
+	d1 := vec.Sign(orientation(o.From, o.To, s.From))
+	d2 := vec.Sign(orientation(o.From, o.To, s.To))
+	d3 := vec.Sign(orientation(s.From, s.To, o.From))
+	d4 := vec.Sign(orientation(s.From, s.To, o.To))
+
+	if d1*d2 < 0 && d3*d4 < 0 {
+		return true
+	}
+
+	return o.Contains(s.From) || o.Contains(s.To) || s.Contains(o.From) || s.Contains(o.To)
+}
+
+// LatticePoints returns the number of lattice points on the segment
+// excluding its end.
+func (s Segment) LatticePoints() int {
+	d := s.To.Sub(s.From)
+	return gcd(vec.Abs(d.X), vec.Abs(d.Y))
+}
+
+func (s Segment) String() string {
+	return fmt.Sprintf("(%v, %v)", s.From, s.To)
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
@@ -0,0 +1,124 @@
+package graph
+
+import (
+	"aoc/pkg/sets"
+	"aoc/pkg/unionfind"
+)
+
+// StronglyConnectedComponents returns the strongly connected components using
+// Tarjan's algorithm. Components are emitted in reverse topological order of
+// the condensed graph, i.e. sinks come first.
+//
+// https://en.wikipedia.org/wiki/Tarjan%27s_strongly_connected_components_algorithm
+func (g *Graph[N]) StronglyConnectedComponents() [][]N {
+	t := &tarjan[N]{
+		g:       g,
+		index:   make([]int, len(g.nodes)),
+		lowLink: make([]int, len(g.nodes)),
+		onStack: make([]bool, len(g.nodes)),
+	}
+	for i := range t.index {
+		t.index[i] = -1
+	}
+
+	for id := range g.nodes {
+		if t.index[id] < 0 {
+			t.connect(id)
+		}
+	}
+	return t.components
+}
+
+type tarjan[N comparable] struct {
+	g *Graph[N]
+
+	next    int
+	index   []int
+	lowLink []int
+	onStack []bool
+	stack   []int
+
+	components [][]N
+}
+
+func (t *tarjan[N]) connect(v int) {
+	t.index[v] = t.next
+	t.lowLink[v] = t.next
+	t.next++
+	t.stack = append(t.stack, v)
+	t.onStack[v] = true
+
+	for _, e := range t.g.out[v] {
+		w := e.to
+		if t.index[w] < 0 {
+			t.connect(w)
+			t.lowLink[v] = min(t.lowLink[v], t.lowLink[w])
+		} else if t.onStack[w] {
+			t.lowLink[v] = min(t.lowLink[v], t.index[w])
+		}
+	}
+
+	if t.lowLink[v] != t.index[v] {
+		return
+	}
+
+	// v is the root of a component
+	var component []N
+	for {
+		w := t.stack[len(t.stack)-1]
+		t.stack = t.stack[:len(t.stack)-1]
+		t.onStack[w] = false
+		component = append(component, t.g.nodes[w])
+		if w == v {
+			break
+		}
+	}
+	t.components = append(t.components, component)
+}
+
+// ConnectedComponents returns the connected components, ignoring edge
+// direction. Components and their nodes are in insertion order.
+func (g *Graph[N]) ConnectedComponents() [][]N {
+	forest := unionfind.NewDense(len(g.nodes))
+	for f, edges := range g.out {
+		for _, e := range edges {
+			forest.Union(f, e.to)
+		}
+	}
+
+	var components [][]N
+	for _, ids := range forest.Components() {
+		component := make([]N, len(ids))
+		for i, id := range ids {
+			component[i] = g.nodes[id]
+		}
+		components = append(components, component)
+	}
+	return components
+}
+
+// Reachable returns all nodes reachable from start, including start itself.
+func (g *Graph[N]) Reachable(start N) sets.Set[N] {
+	reached := sets.New[N]()
+	s, ok := g.index[start]
+	if !ok {
+		return reached
+	}
+
+	visited := make([]bool, len(g.nodes))
+	visited[s] = true
+	stack := []int{s}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		reached.Put(g.nodes[current])
+
+		for _, e := range g.out[current] {
+			if !visited[e.to] {
+				visited[e.to] = true
+				stack = append(stack, e.to)
+			}
+		}
+	}
+	return reached
+}
@@ -0,0 +1,233 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Edge is a weighted connection between two nodes. Unweighted edges have a
+// weight of 1.
+type Edge[N comparable] struct {
+	From, To N
+	Weight   int
+}
+
+func (e Edge[N]) String() string {
+	return fmt.Sprintf("%v -> %v (%d)", e.From, e.To, e.Weight)
+}
+
+type edge struct {
+	to     int
+	weight int
+}
+
+// Graph is a directed or undirected weighted graph. Nodes are kept in
+// insertion order, which makes all traversals deterministic.
+type Graph[N comparable] struct {
+	directed bool
+
+	index map[N]int
+	nodes []N
+	out   [][]edge
+	in    [][]edge
+}
+
+func NewDirected[N comparable]() *Graph[N] {
+	return newGraph[N](true)
+}
+
+func NewUndirected[N comparable]() *Graph[N] {
+	return newGraph[N](false)
+}
+
+func newGraph[N comparable](directed bool) *Graph[N] {
+	return &Graph[N]{
+		directed: directed,
+		index:    make(map[N]int),
+	}
+}
+
+func (g *Graph[N]) Directed() bool {
+	return g.directed
+}
+
+// Len returns the number of nodes.
+func (g *Graph[N]) Len() int {
+	return len(g.nodes)
+}
+
+// AddNode inserts n if it is not yet part of the graph.
+func (g *Graph[N]) AddNode(n N) {
+	g.id(n)
+}
+
+func (g *Graph[N]) id(n N) int {
+	if i, ok := g.index[n]; ok {
+		return i
+	}
+	i := len(g.nodes)
+	g.index[n] = i
+	g.nodes = append(g.nodes, n)
+	g.out = append(g.out, nil)
+	g.in = append(g.in, nil)
+	return i
+}
+
+func (g *Graph[N]) HasNode(n N) bool {
+	_, ok := g.index[n]
+	return ok
+}
+
+// AddEdge connects from and to with a weight of 1, adding missing nodes.
+func (g *Graph[N]) AddEdge(from, to N) {
+	g.AddWeightedEdge(from, to, 1)
+}
+
+// AddWeightedEdge connects from and to, adding missing nodes. In undirected
+// graphs the edge is traversable both ways.
+func (g *Graph[N]) AddWeightedEdge(from, to N, weight int) {
+	f, t := g.id(from), g.id(to)
+	g.addEdge(f, t, weight)
+}
+
+func (g *Graph[N]) addEdge(f, t, weight int) {
+	g.out[f] = append(g.out[f], edge{to: t, weight: weight})
+	g.in[t] = append(g.in[t], edge{to: f, weight: weight})
+	if !g.directed && f != t {
+		g.out[t] = append(g.out[t], edge{to: f, weight: weight})
+		g.in[f] = append(g.in[f], edge{to: t, weight: weight})
+	}
+}
+
+func (g *Graph[N]) HasEdge(from, to N) bool {
+	f, ok := g.index[from]
+	if !ok {
+		return false
+	}
+	t, ok := g.index[to]
+	if !ok {
+		return false
+	}
+	for _, e := range g.out[f] {
+		if e.to == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Nodes returns all nodes in insertion order.
+func (g *Graph[N]) Nodes() []N {
+	nodes := make([]N, len(g.nodes))
+	copy(nodes, g.nodes)
+	return nodes
+}
+
+// Neighbors returns the nodes reachable from n via a single edge.
+func (g *Graph[N]) Neighbors(n N) []N {
+	i, ok := g.index[n]
+	if !ok {
+		return nil
+	}
+	neighbors := make([]N, len(g.out[i]))
+	for j, e := range g.out[i] {
+		neighbors[j] = g.nodes[e.to]
+	}
+	return neighbors
+}
+
+// Predecessors returns the nodes with an edge leading to n.
+func (g *Graph[N]) Predecessors(n N) []N {
+	i, ok := g.index[n]
+	if !ok {
+		return nil
+	}
+	predecessors := make([]N, len(g.in[i]))
+	for j, e := range g.in[i] {
+		predecessors[j] = g.nodes[e.to]
+	}
+	return predecessors
+}
+
+// Edges returns the outgoing edges of n.
+func (g *Graph[N]) Edges(n N) []Edge[N] {
+	i, ok := g.index[n]
+	if !ok {
+		return nil
+	}
+	edges := make([]Edge[N], len(g.out[i]))
+	for j, e := range g.out[i] {
+		edges[j] = Edge[N]{From: n, To: g.nodes[e.to], Weight: e.weight}
+	}
+	return edges
+}
+
+func (g *Graph[N]) OutDegree(n N) int {
+	i, ok := g.index[n]
+	if !ok {
+		return 0
+	}
+	return len(g.out[i])
+}
+
+func (g *Graph[N]) InDegree(n N) int {
+	i, ok := g.index[n]
+	if !ok {
+		return 0
+	}
+	return len(g.in[i])
+}
+
+// Subgraph returns the graph induced by all nodes matching keep.
+func (g *Graph[N]) Subgraph(keep func(n N) bool) *Graph[N] {
+	sub := newGraph[N](g.directed)
+
+	mapped := make([]int, len(g.nodes))
+	for i, n := range g.nodes {
+		mapped[i] = -1
+		if keep(n) {
+			mapped[i] = sub.id(n)
+		}
+	}
+
+	for f, edges := range g.out {
+		if mapped[f] < 0 {
+			continue
+		}
+		for _, e := range edges {
+			if mapped[e.to] < 0 {
+				continue
+			}
+			// undirected edges are stored twice, only add them once
+			if !g.directed && e.to < f {
+				continue
+			}
+			sub.addEdge(mapped[f], mapped[e.to], e.weight)
+		}
+	}
+	return sub
+}
+
+// String renders the graph in the graphviz dot format.
+func (g *Graph[N]) String() string {
+	var buf strings.Builder
+	kind, arrow := "digraph", "->"
+	if !g.directed {
+		kind, arrow = "graph", "--"
+	}
+
+	fmt.Fprintf(&buf, "%s {\n", kind)
+	for i, n := range g.nodes {
+		fmt.Fprintf(&buf, "  %d [label=%q]\n", i, fmt.Sprintf("%v", n))
+	}
+	for f, edges := range g.out {
+		for _, e := range edges {
+			if !g.directed && e.to < f {
+				continue
+			}
+			fmt.Fprintf(&buf, "  %d %s %d [label=%d]\n", f, arrow, e.to, e.weight)
+		}
+	}
+	buf.WriteString("}\n")
+	return buf.String()
+}
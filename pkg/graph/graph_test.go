@@ -0,0 +1,203 @@
+package graph
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"testing"
+
+	"aoc/pkg/be"
+)
+
+func TestTopoSort(t *testing.T) {
+	g := NewDirected[int]()
+	g.AddEdge(5, 11)
+	g.AddEdge(7, 11)
+	g.AddEdge(7, 8)
+	g.AddEdge(3, 8)
+	g.AddEdge(3, 10)
+	g.AddEdge(11, 2)
+	g.AddEdge(11, 9)
+	g.AddEdge(11, 10)
+	g.AddEdge(8, 9)
+
+	sorted, err := g.TopoSort(cmp.Compare[int])
+	be.NoError(t, err)
+	be.True(t, slices.Equal(sorted, []int{3, 5, 7, 8, 11, 2, 9, 10}))
+
+	sorted, err = g.TopoSort(nil)
+	be.NoError(t, err)
+	be.True(t, slices.Equal(sorted, []int{5, 7, 11, 3, 8, 10, 2, 9}))
+}
+
+func TestTopoSort_Cycle(t *testing.T) {
+	g := NewDirected[string]()
+	g.AddEdge("a", "b")
+	g.AddEdge("b", "c")
+	g.AddEdge("c", "d")
+	g.AddEdge("d", "b")
+
+	_, err := g.TopoSort(nil)
+	be.AnError(t, err)
+
+	var cycleErr *CycleError[string]
+	be.True(t, errors.As(err, &cycleErr))
+	be.True(t, slices.Equal(cycleErr.Cycle, []string{"b", "c", "d"}))
+}
+
+func TestStronglyConnectedComponents(t *testing.T) {
+	g := NewDirected[string]()
+	g.AddEdge("a", "b")
+	g.AddEdge("b", "c")
+	g.AddEdge("c", "a")
+	g.AddEdge("c", "d")
+	g.AddEdge("d", "e")
+	g.AddEdge("e", "d")
+	g.AddNode("f")
+
+	components := g.StronglyConnectedComponents()
+	be.Equal(t, len(components), 3)
+
+	for _, c := range components {
+		slices.Sort(c)
+	}
+	be.True(t, slices.Equal(components[0], []string{"d", "e"}))
+	be.True(t, slices.Equal(components[1], []string{"a", "b", "c"}))
+	be.True(t, slices.Equal(components[2], []string{"f"}))
+}
+
+func TestConnectedComponents(t *testing.T) {
+	g := NewUndirected[int]()
+	g.AddEdge(1, 2)
+	g.AddEdge(3, 4)
+	g.AddEdge(2, 5)
+	g.AddNode(6)
+
+	components := g.ConnectedComponents()
+	be.Equal(t, len(components), 3)
+	be.True(t, slices.Equal(components[0], []int{1, 2, 5}))
+	be.True(t, slices.Equal(components[1], []int{3, 4}))
+	be.True(t, slices.Equal(components[2], []int{6}))
+}
+
+func TestReachable(t *testing.T) {
+	g := NewDirected[int]()
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 3)
+	g.AddEdge(4, 1)
+
+	be.Equal(t, g.Reachable(1).String(), "{ 1, 2, 3 }")
+	be.Equal(t, g.Reachable(3).String(), "{ 3 }")
+}
+
+func TestCountPaths(t *testing.T) {
+	// diamond chain with 2*2 paths
+	g := NewDirected[string]()
+	g.AddEdge("s", "a")
+	g.AddEdge("s", "b")
+	g.AddEdge("a", "m")
+	g.AddEdge("b", "m")
+	g.AddEdge("m", "c")
+	g.AddEdge("m", "d")
+	g.AddEdge("c", "t")
+	g.AddEdge("d", "t")
+	g.AddEdge("d", "x")
+
+	n, err := g.CountPaths("s", "t")
+	be.NoError(t, err)
+	be.Equal(t, n, 4)
+
+	n, err = g.CountPathsToSinks("s")
+	be.NoError(t, err)
+	be.Equal(t, n, 6)
+}
+
+func TestCountPaths_UnreachableCycle(t *testing.T) {
+	g := NewDirected[string]()
+	g.AddEdge("s", "a")
+	g.AddEdge("s", "t")
+	g.AddEdge("a", "t")
+	// a cycle in another component, and one that leads into t only
+	g.AddEdge("x", "y")
+	g.AddEdge("y", "x")
+	g.AddEdge("y", "t")
+
+	n, err := g.CountPaths("s", "t")
+	be.NoError(t, err)
+	be.Equal(t, n, 2)
+
+	n, err = g.CountPathsToSinks("s")
+	be.NoError(t, err)
+	be.Equal(t, n, 2)
+
+	// the cycle matters once it is reachable
+	_, err = g.CountPaths("x", "t")
+	var cycleErr *CycleError[string]
+	be.True(t, errors.As(err, &cycleErr))
+	be.True(t, slices.Equal(cycleErr.Cycle, []string{"x", "y"}))
+}
+
+func TestSubgraph(t *testing.T) {
+	g := NewUndirected[int]()
+	g.AddWeightedEdge(1, 2, 3)
+	g.AddWeightedEdge(2, 3, 4)
+	g.AddWeightedEdge(3, 1, 5)
+
+	sub := g.Subgraph(func(n int) bool { return n != 2 })
+	be.Equal(t, sub.Len(), 2)
+	be.True(t, sub.HasEdge(1, 3))
+	be.True(t, sub.HasEdge(3, 1))
+	be.True(t, !sub.HasNode(2))
+	be.Equal(t, sub.Edges(1)[0].Weight, 5)
+	be.Equal(t, len(sub.Edges(1)), 1)
+}
+
+func TestShortestPaths(t *testing.T) {
+	g := NewUndirected[string]()
+	g.AddWeightedEdge("a", "b", 7)
+	g.AddWeightedEdge("a", "c", 9)
+	g.AddWeightedEdge("a", "f", 14)
+	g.AddWeightedEdge("b", "c", 10)
+	g.AddWeightedEdge("b", "d", 15)
+	g.AddWeightedEdge("c", "d", 11)
+	g.AddWeightedEdge("c", "f", 2)
+	g.AddWeightedEdge("d", "e", 6)
+	g.AddWeightedEdge("e", "f", 9)
+	g.AddNode("x")
+
+	dist := g.ShortestPaths("a")
+	be.Equal(t, dist["e"], 20)
+	be.Equal(t, dist["f"], 11)
+	_, ok := dist["x"]
+	be.True(t, !ok)
+
+	fw := g.FloydWarshall()
+	apsp := g.AllPairsShortestPaths()
+	for i := range fw.Dist {
+		be.True(t, slices.Equal(fw.Dist[i], apsp.Dist[i]))
+	}
+
+	d, ok := fw.Distance("b", "f")
+	be.True(t, ok)
+	be.Equal(t, d, 12)
+
+	_, ok = fw.Distance("a", "x")
+	be.True(t, !ok)
+}
+
+func TestContract(t *testing.T) {
+	// AA - zz - BB - yy - CC, only the uppercase nodes are interesting
+	g := NewUndirected[string]()
+	g.AddEdge("AA", "zz")
+	g.AddEdge("zz", "BB")
+	g.AddEdge("BB", "yy")
+	g.AddEdge("yy", "CC")
+	g.AddWeightedEdge("AA", "CC", 10)
+
+	m := g.Contract([]string{"AA", "CC", "BB"})
+	be.Equal(t, m.Len(), 3)
+	be.Equal(t, m.Index["CC"], 1)
+	be.Equal(t, m.Dist[0][1], 4)
+	be.Equal(t, m.Dist[0][2], 2)
+	be.Equal(t, m.Dist[1][2], 2)
+}
@@ -0,0 +1,70 @@
+package graph
+
+import (
+	"fmt"
+)
+
+// CountPaths returns the number of distinct paths from one node to another.
+// The part of the graph reachable from from must be acyclic.
+func (g *Graph[N]) CountPaths(from, to N) (int, error) {
+	t, ok := g.index[to]
+	if !ok {
+		return 0, nil
+	}
+	return g.countPaths(from, func(id int) bool { return id == t })
+}
+
+// CountPathsToSinks returns the number of distinct paths from a node to any
+// node without outgoing edges. The part of the graph reachable from from
+// must be acyclic.
+func (g *Graph[N]) CountPathsToSinks(from N) (int, error) {
+	return g.countPaths(from, func(id int) bool { return len(g.out[id]) == 0 })
+}
+
+func (g *Graph[N]) countPaths(from N, isTarget func(id int) bool) (int, error) {
+	if !g.directed {
+		panic(fmt.Errorf("counting paths in an undirected graph"))
+	}
+
+	f, ok := g.index[from]
+	if !ok {
+		return 0, nil
+	}
+
+	order, err := g.topoOrderOf(g.reachable(f), nil)
+	if err != nil {
+		return 0, err
+	}
+
+	// count backwards, every node knows the paths of its successors already
+	counts := make([]int, len(g.nodes))
+	for i := len(order) - 1; i >= 0; i-- {
+		id := order[i]
+		if isTarget(id) {
+			counts[id] = 1
+			continue
+		}
+		for _, e := range g.out[id] {
+			counts[id] += counts[e.to]
+		}
+	}
+	return counts[f], nil
+}
+
+// reachable marks the nodes that can be reached from start, including start.
+func (g *Graph[N]) reachable(start int) []bool {
+	seen := make([]bool, len(g.nodes))
+	seen[start] = true
+	stack := []int{start}
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, e := range g.out[id] {
+			if !seen[e.to] {
+				seen[e.to] = true
+				stack = append(stack, e.to)
+			}
+		}
+	}
+	return seen
+}
@@ -0,0 +1,188 @@
+package graph
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"strings"
+
+	"aoc/pkg/heapq"
+)
+
+// Unreachable is the distance between two nodes without a connecting path.
+const Unreachable = math.MaxInt
+
+// DistanceMatrix holds the shortest path lengths between a set of nodes.
+// Nodes are addressed by a compact index, which makes the matrix usable for
+// bitmask based searches.
+type DistanceMatrix[N comparable] struct {
+	// Nodes maps an index to its node
+	Nodes []N
+
+	// Index maps a node to its index
+	Index map[N]int
+
+	// Dist holds the distance between two indices, or Unreachable
+	Dist [][]int
+}
+
+func newDistanceMatrix[N comparable](nodes []N) *DistanceMatrix[N] {
+	m := &DistanceMatrix[N]{
+		Nodes: nodes,
+		Index: make(map[N]int, len(nodes)),
+		Dist:  make([][]int, len(nodes)),
+	}
+	for i, n := range nodes {
+		m.Index[n] = i
+		m.Dist[i] = make([]int, len(nodes))
+		for j := range m.Dist[i] {
+			m.Dist[i][j] = Unreachable
+		}
+		m.Dist[i][i] = 0
+	}
+	return m
+}
+
+func (m *DistanceMatrix[N]) Len() int {
+	return len(m.Nodes)
+}
+
+// Distance returns the shortest distance from a to b, false if there is no path.
+func (m *DistanceMatrix[N]) Distance(a, b N) (int, bool) {
+	i, ok := m.Index[a]
+	if !ok {
+		return Unreachable, false
+	}
+	j, ok := m.Index[b]
+	if !ok {
+		return Unreachable, false
+	}
+	d := m.Dist[i][j]
+	return d, d != Unreachable
+}
+
+func (m *DistanceMatrix[N]) String() string {
+	var buf strings.Builder
+	for i, row := range m.Dist {
+		fmt.Fprintf(&buf, "%v:", m.Nodes[i])
+		for _, d := range row {
+			if d == Unreachable {
+				buf.WriteString(" -")
+			} else {
+				fmt.Fprintf(&buf, " %d", d)
+			}
+		}
+		buf.WriteString("\n")
+	}
+	return buf.String()
+}
+
+// FloydWarshall computes the shortest distances between all pairs of nodes,
+// indexed in insertion order. Negative weights are fine as long as there are
+// no negative cycles.
+//
+// https://en.wikipedia.org/wiki/Floyd%E2%80%93Warshall_algorithm
+func (g *Graph[N]) FloydWarshall() *DistanceMatrix[N] {
+	m := newDistanceMatrix(g.Nodes())
+	dist := m.Dist
+	for f, edges := range g.out {
+		for _, e := range edges {
+			dist[f][e.to] = min(dist[f][e.to], e.weight)
+		}
+	}
+
+	for k := range dist {
+		for i := range dist {
+			if dist[i][k] == Unreachable {
+				continue
+			}
+			for j := range dist {
+				if dist[k][j] == Unreachable {
+					continue
+				}
+				dist[i][j] = min(dist[i][j], dist[i][k]+dist[k][j])
+			}
+		}
+	}
+	return m
+}
+
+// ShortestPaths returns the distance to every node reachable from start using
+// Dijkstra's algorithm. All weights must be non-negative.
+func (g *Graph[N]) ShortestPaths(start N) map[N]int {
+	s, ok := g.index[start]
+	if !ok {
+		return nil
+	}
+
+	dist := g.dijkstra(s)
+	r := make(map[N]int)
+	for id, d := range dist {
+		if d != Unreachable {
+			r[g.nodes[id]] = d
+		}
+	}
+	return r
+}
+
+// AllPairsShortestPaths computes the shortest distances between all pairs of
+// nodes by running Dijkstra from every node, which beats FloydWarshall on
+// sparse graphs. All weights must be non-negative.
+func (g *Graph[N]) AllPairsShortestPaths() *DistanceMatrix[N] {
+	m := newDistanceMatrix(g.Nodes())
+	for id := range g.nodes {
+		m.Dist[id] = g.dijkstra(id)
+	}
+	return m
+}
+
+// Contract reduces the graph to the given key nodes, the resulting matrix is
+// indexed in the order of keys. All other nodes only serve as waypoints.
+// All weights must be non-negative.
+func (g *Graph[N]) Contract(keys []N) *DistanceMatrix[N] {
+	m := newDistanceMatrix(keys)
+	for i, k := range keys {
+		s, ok := g.index[k]
+		if !ok {
+			continue
+		}
+		dist := g.dijkstra(s)
+		for j, other := range keys {
+			if o, ok := g.index[other]; ok {
+				m.Dist[i][j] = dist[o]
+			}
+		}
+	}
+	return m
+}
+
+func (g *Graph[N]) dijkstra(start int) []int {
+	dist := make([]int, len(g.nodes))
+	for i := range dist {
+		dist[i] = Unreachable
+	}
+	dist[start] = 0
+
+	q := heapq.New(func(a, b distEntry) int { return cmp.Compare(a.dist, b.dist) })
+	q.Push(distEntry{id: start})
+	for q.Len() > 0 {
+		current, _ := q.Pop()
+		if current.dist > dist[current.id] {
+			// stale entry, we found a shorter way already
+			continue
+		}
+		for _, e := range g.out[current.id] {
+			d := current.dist + e.weight
+			if d < dist[e.to] {
+				dist[e.to] = d
+				q.Push(distEntry{id: e.to, dist: d})
+			}
+		}
+	}
+	return dist
+}
+
+type distEntry struct {
+	id   int
+	dist int
+}
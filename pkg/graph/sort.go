@@ -0,0 +1,138 @@
+package graph
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"aoc/pkg/heapq"
+)
+
+// CycleError is returned when an operation requires an acyclic graph.
+type CycleError[N comparable] struct {
+	// Cycle lists the nodes of one cycle, the last node leads back to the first
+	Cycle []N
+}
+
+func (e *CycleError[N]) Error() string {
+	return fmt.Sprintf("graph has a cycle: %v", e.Cycle)
+}
+
+// TopoSort orders the nodes of a directed graph such that every edge points
+// forward. Among nodes that are ready at the same time the smallest
+// according to cmp comes first, with a nil comparator insertion order is
+// used. A *CycleError is returned if the graph is not acyclic.
+func (g *Graph[N]) TopoSort(cmp func(a, b N) int) ([]N, error) {
+	if !g.directed {
+		panic(fmt.Errorf("topological sort of an undirected graph"))
+	}
+
+	order, err := g.topoOrder(cmp)
+	if err != nil {
+		return nil, err
+	}
+
+	sorted := make([]N, len(order))
+	for i, id := range order {
+		sorted[i] = g.nodes[id]
+	}
+	return sorted, nil
+}
+
+// topoOrder runs Kahn's algorithm on the node ids
+func (g *Graph[N]) topoOrder(compare func(a, b N) int) ([]int, error) {
+	all := make([]bool, len(g.nodes))
+	for id := range all {
+		all[id] = true
+	}
+	return g.topoOrderOf(all, compare)
+}
+
+// topoOrderOf runs Kahn's algorithm on the node ids in the subgraph induced
+// by the nodes in include.
+func (g *Graph[N]) topoOrderOf(include []bool, compare func(a, b N) int) ([]int, error) {
+	order := func(a, b int) int {
+		if compare != nil {
+			if r := compare(g.nodes[a], g.nodes[b]); r != 0 {
+				return r
+			}
+		}
+		return cmp.Compare(a, b)
+	}
+
+	inDegrees := make([]int, len(g.nodes))
+	size := 0
+	for from, edges := range g.out {
+		if !include[from] {
+			continue
+		}
+		size++
+		for _, e := range edges {
+			if include[e.to] {
+				inDegrees[e.to]++
+			}
+		}
+	}
+
+	ready := heapq.New(order)
+	for id, d := range inDegrees {
+		if include[id] && d == 0 {
+			ready.Push(id)
+		}
+	}
+
+	sorted := make([]int, 0, size)
+	for ready.Len() > 0 {
+		id, _ := ready.Pop()
+		sorted = append(sorted, id)
+		for _, e := range g.out[id] {
+			if !include[e.to] {
+				continue
+			}
+			inDegrees[e.to]--
+			if inDegrees[e.to] == 0 {
+				ready.Push(e.to)
+			}
+		}
+	}
+
+	if len(sorted) < size {
+		return nil, &CycleError[N]{Cycle: g.findCycle(inDegrees)}
+	}
+	return sorted, nil
+}
+
+// findCycle walks backwards through nodes Kahn's algorithm could not remove,
+// each of them has a predecessor that is also part of the remainder.
+func (g *Graph[N]) findCycle(inDegrees []int) []N {
+	start := slices.IndexFunc(inDegrees, func(d int) bool { return d > 0 })
+
+	seen := make(map[int]int)
+	var path []int
+	current := start
+	for {
+		if at, ok := seen[current]; ok {
+			path = path[at:]
+			break
+		}
+		seen[current] = len(path)
+		path = append(path, current)
+
+		for _, e := range g.in[current] {
+			if inDegrees[e.to] > 0 {
+				current = e.to
+				break
+			}
+		}
+	}
+
+	// we walked against the edges, start at the earliest inserted node
+	slices.Reverse(path)
+	first := slices.Index(path, slices.Min(path))
+	path = append(path[first:], path[:first]...)
+	cycle := make([]N, len(path))
+	for i, id := range path {
+		cycle[i] = g.nodes[id]
+	}
+	return cycle
+}
@@ -4,12 +4,25 @@ type node[E any] struct {
 	element E
 	next    *node[E]
 }
+
+// Queue is a binary min-heap, Pop returns the smallest element according
+// to the comparator.
 type Queue[S []E, E any] struct {
 	arr        S
 	length     int
 	comparator func(a, b E) int
 }
 
+// New returns an empty queue ordered by comparator, which compares like
+// cmp.Compare.
+func New[E any](comparator func(a, b E) int) *Queue[[]E, E] {
+	return &Queue[[]E, E]{comparator: comparator}
+}
+
+func (q *Queue[S, E]) Len() int {
+	return q.length
+}
+
 func (q *Queue[S, E]) Push(element E) {
 	p := q.length
 	if len(q.arr) <= p {
@@ -28,7 +41,7 @@ func (q *Queue[S, E]) Push(element E) {
 func (q *Queue[S, E]) swim(p int) {
 	for {
 		parent := (p - 1) / 2
-		if q.comparator(q.arr[parent], q.arr[p]) < 0 {
+		if p > 0 && q.comparator(q.arr[p], q.arr[parent]) < 0 {
 			q.arr[parent], q.arr[p] = q.arr[p], q.arr[parent]
 		} else {
 			return
@@ -41,7 +54,7 @@ func (q *Queue[S, E]) swim(p int) {
 }
 
 func (q *Queue[S, E]) grow() {
-	narr := make(S, len(q.arr)*2)
+	narr := make(S, max(4, len(q.arr)*2))
 	copy(narr, q.arr)
 	q.arr = narr
 }
@@ -67,16 +80,16 @@ func (q *Queue[S, E]) Pop() (E, bool) {
 func (q *Queue[S, E]) sink() {
 	p := 0
 	for {
-		l := 2*p + 1
-		r := 2*p + 2
-		if q.comparator(q.arr[l], q.arr[p]) < 0 && l < q.length {
-			q.arr[l], q.arr[p] = q.arr[p], q.arr[l]
-			p = l
-		} else if q.comparator(q.arr[r], q.arr[p]) < 0 && r < q.length {
-			q.arr[r], q.arr[p] = q.arr[p], q.arr[r]
-			p = r
-		} else {
+		smallest := p
+		for _, c := range []int{2*p + 1, 2*p + 2} {
+			if c < q.length && q.comparator(q.arr[c], q.arr[smallest]) < 0 {
+				smallest = c
+			}
+		}
+		if smallest == p {
 			return
 		}
+		q.arr[smallest], q.arr[p] = q.arr[p], q.arr[smallest]
+		p = smallest
 	}
 }
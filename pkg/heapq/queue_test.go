@@ -1,6 +1,49 @@
 package heapq
 
-import "testing"
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"aoc/pkg/be"
+)
 
 func TestName(t *testing.T) {
 }
+
+func TestQueue(t *testing.T) {
+	q := New(cmp.Compare[int])
+	_, ok := q.Pop()
+	be.True(t, !ok)
+
+	rnd := rand.New(rand.NewSource(1))
+	values := make([]int, 100)
+	for i := range values {
+		values[i] = rnd.Intn(50)
+		q.Push(values[i])
+	}
+	be.Equal(t, q.Len(), 100)
+
+	slices.Sort(values)
+	for _, want := range values {
+		v, ok := q.Pop()
+		be.True(t, ok)
+		be.Equal(t, v, want)
+	}
+	be.Equal(t, q.Len(), 0)
+}
+
+func TestQueueInterleaved(t *testing.T) {
+	q := New(func(a, b string) int { return cmp.Compare(len(a), len(b)) })
+	q.Push("ccc")
+	q.Push("a")
+	v, _ := q.Pop()
+	be.Equal(t, v, "a")
+	q.Push("bb")
+	q.Push("dddd")
+	v, _ = q.Pop()
+	be.Equal(t, v, "bb")
+	v, _ = q.Pop()
+	be.Equal(t, v, "ccc")
+}
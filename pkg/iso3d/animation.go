@@ -0,0 +1,154 @@
+package iso3d
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+
+	"aoc/pkg/vec"
+)
+
+// Animation records a scene per step and renders them as frames of equal
+// size, with one floor and view that fits all steps, so nothing jumps.
+type Animation struct {
+	Camera  Camera
+	Options RenderOptions
+
+	// Delay is the time each frame is shown in 100ths of a second.
+	Delay int
+
+	// LoopCount is the number of times the GIF repeats, 0 loops forever
+	// and -1 plays it once.
+	LoopCount int
+
+	scenes [][]Cuboid
+}
+
+func NewAnimation(cam Camera) *Animation {
+	return &Animation{Camera: cam, Delay: 10}
+}
+
+// AddFrame records the scene of one step. The cuboids are copied, the
+// caller may keep changing them for the next step.
+func (a *Animation) AddFrame(cubes []*Cuboid) {
+	scene := make([]Cuboid, len(cubes))
+	for i, c := range cubes {
+		scene[i] = *c
+	}
+	a.scenes = append(a.scenes, scene)
+}
+
+// Record calls scene for each of the steps and adds its result as a frame.
+func (a *Animation) Record(steps int, scene func(step int) []*Cuboid) {
+	for step := 0; step < steps; step++ {
+		a.AddFrame(scene(step))
+	}
+}
+
+func (a *Animation) Len() int {
+	return len(a.scenes)
+}
+
+// Frames renders all recorded scenes.
+func (a *Animation) Frames() []*image.RGBA {
+	canvases := make([]*Canvas, len(a.scenes))
+	floor := vec.AABB{From: vec.Vec2i{X: 1, Y: 1}}
+	for i, scene := range a.scenes {
+		canvases[i] = NewFittedCanvas(a.Camera)
+		canvases[i].Options = a.Options
+		for j := range scene {
+			canvases[i].AddCube(&scene[j])
+		}
+		floor = floor.Union(canvases[i].floor)
+	}
+
+	var bounds vec.AABB
+	for i, c := range canvases {
+		c.floor = floor
+		if i == 0 {
+			bounds = c.sceneBounds()
+		} else {
+			bounds = bounds.Union(c.sceneBounds())
+		}
+	}
+
+	frames := make([]*image.RGBA, len(canvases))
+	for i, c := range canvases {
+		c.layout(bounds)
+		c.clear()
+		c.drawFloor()
+		c.drawFaces()
+		c.drawOverlays()
+		frames[i] = c.img
+	}
+	return frames
+}
+
+// EncodeGIF writes all frames as animated GIF. The palette is shared by all
+// frames and holds every colour used, unless there are too many of them.
+func (a *Animation) EncodeGIF(w io.Writer) error {
+	frames := a.Frames()
+	if len(frames) == 0 {
+		return fmt.Errorf("animation has no frames")
+	}
+
+	pal := framePalette(frames)
+	anim := &gif.GIF{LoopCount: a.LoopCount}
+	for _, frame := range frames {
+		paletted := image.NewPaletted(frame.Bounds(), pal)
+		draw.Draw(paletted, paletted.Bounds(), frame, image.Point{}, draw.Src)
+		anim.Image = append(anim.Image, paletted)
+		anim.Delay = append(anim.Delay, a.Delay)
+	}
+	return gif.EncodeAll(w, anim)
+}
+
+// framePalette collects the colours of all frames, falling back to a
+// generic palette if they don't fit into a GIF.
+func framePalette(frames []*image.RGBA) color.Palette {
+	seen := map[color.RGBA]bool{}
+	var pal color.Palette
+	for _, frame := range frames {
+		for i := 0; i+3 < len(frame.Pix); i += 4 {
+			c := color.RGBA{R: frame.Pix[i], G: frame.Pix[i+1], B: frame.Pix[i+2], A: frame.Pix[i+3]}
+			if seen[c] {
+				continue
+			}
+			if len(seen) == 256 {
+				return palette.Plan9
+			}
+			seen[c] = true
+			pal = append(pal, c)
+		}
+	}
+	return pal
+}
+
+// WritePNGFrames writes every frame as numbered PNG into dir, e.g. to feed
+// an external encoder.
+func (a *Animation) WritePNGFrames(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	for i, frame := range a.Frames() {
+		f, err := os.Create(filepath.Join(dir, fmt.Sprintf("frame_%05d.png", i)))
+		if err != nil {
+			return err
+		}
+		err = png.Encode(f, frame)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
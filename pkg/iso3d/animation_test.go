@@ -0,0 +1,60 @@
+package iso3d
+
+import (
+	"bytes"
+	"image/gif"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"aoc/pkg/be"
+	"aoc/pkg/vec"
+)
+
+func fallingBrick(step int) []*Cuboid {
+	return []*Cuboid{
+		{Size: vec.Vec3i{X: 3, Y: 3, Z: 1}, Color: colorPalette[0]},
+		{Position: vec.Vec3i{X: 1, Y: 1, Z: 6 - step}, Size: vec.Vec3i{X: 1, Y: 1, Z: 1}, Color: colorPalette[1]},
+	}
+}
+
+func TestAnimation_EncodeGIF(t *testing.T) {
+	anim := NewAnimation(Camera{TileWidth: 16, Margin: 2})
+	anim.Delay = 5
+	anim.Record(6, fallingBrick)
+
+	var buf bytes.Buffer
+	be.NoError(t, anim.EncodeGIF(&buf))
+
+	decoded, err := gif.DecodeAll(&buf)
+	be.NoError(t, err)
+	be.Equal(t, len(decoded.Image), 6)
+	be.Equal(t, decoded.Delay[0], 5)
+	be.Equal(t, decoded.LoopCount, 0)
+
+	// the view fits the highest brick in every frame
+	first := decoded.Image[0].Bounds()
+	for _, frame := range decoded.Image {
+		be.Equal(t, frame.Bounds(), first)
+	}
+	be.True(t, len(decoded.Image[0].Palette) <= 8)
+}
+
+func TestAnimation_EncodeGIFEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	be.AnError(t, NewAnimation(DefaultCamera).EncodeGIF(&buf))
+}
+
+func TestAnimation_WritePNGFrames(t *testing.T) {
+	anim := NewAnimation(DefaultCamera)
+	anim.Record(3, fallingBrick)
+
+	dir := t.TempDir()
+	be.NoError(t, anim.WritePNGFrames(dir))
+
+	entries, err := os.ReadDir(dir)
+	be.NoError(t, err)
+	be.Equal(t, len(entries), 3)
+	_, err = os.Stat(filepath.Join(dir, "frame_00002.png"))
+	be.NoError(t, err)
+}
@@ -0,0 +1,73 @@
+package iso3d
+
+import (
+	"aoc/pkg/vec"
+)
+
+// Corner is the corner of the scene facing the viewer. The scene is turned
+// around the Z axis so that this corner ends up in front.
+type Corner uint8
+
+const (
+	CornerMinXMinY Corner = iota
+	CornerMaxXMinY
+	CornerMaxXMaxY
+	CornerMinXMaxY
+)
+
+// cornerRotations turn the scene such that the corner ends up at min X and
+// min Y, which is the one facing the viewer in the projection.
+var cornerRotations = [...]vec.Mat3i{
+	CornerMinXMinY: vec.Identity3i(),
+	CornerMaxXMinY: {{0, 1, 0}, {-1, 0, 0}, {0, 0, 1}},
+	CornerMaxXMaxY: {{-1, 0, 0}, {0, -1, 0}, {0, 0, 1}},
+	CornerMinXMaxY: {{0, -1, 0}, {1, 0, 0}, {0, 0, 1}},
+}
+
+// Camera controls the projection of a scene onto a canvas.
+type Camera struct {
+	// TileWidth is the width in pixels of a unit tile, its height is
+	// half of it. Larger tiles zoom in.
+	TileWidth int
+
+	// Corner is the corner of the scene facing the viewer.
+	Corner Corner
+
+	// Margin is the free space in pixels kept around a fitted scene.
+	Margin int
+}
+
+// DefaultCamera is the camera NewCanvas projects with.
+var DefaultCamera = Camera{TileWidth: 16, Margin: 8}
+
+func (cam Camera) tileWidthHalf() int {
+	return max(1, cam.TileWidth/2)
+}
+
+func (cam Camera) tileHeightHalf() int {
+	return max(1, cam.TileWidth/4)
+}
+
+// project maps p to pixel coordinates with Y growing upwards.
+func (cam Camera) project(p vec.Vec3i) vec.Vec2i {
+	x := (p.X-p.Y)*cam.tileWidthHalf() - cam.tileWidthHalf()
+	y := (p.X + p.Y) * cam.tileHeightHalf()
+	y += p.Z * 2 * cam.tileHeightHalf()
+	return vec.Vec2i{X: x, Y: y}
+}
+
+// orient turns the cuboid for the camera corner. Its cells are rotated as a
+// whole, so the result occupies exactly the rotated cells.
+func (cam Camera) orient(c *Cuboid) *Cuboid {
+	if cam.Corner == CornerMinXMinY {
+		return c
+	}
+	rot := cornerRotations[cam.Corner]
+	cells := c.Bounds()
+	rotated := vec.BoundingBox3i([]vec.Vec3i{rot.Mul(cells.From), rot.Mul(cells.To)})
+	return &Cuboid{
+		Position: rotated.From,
+		Size:     rotated.Size(),
+		Color:    c.Color,
+	}
+}
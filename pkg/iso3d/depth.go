@@ -0,0 +1,200 @@
+package iso3d
+
+import (
+	"cmp"
+	"slices"
+
+	"aoc/pkg/graph"
+	"aoc/pkg/vec"
+)
+
+// Depth ordering works in tile units of the projection: a = X-Y grows to
+// the right, b = X+Y+2Z grows upwards and d = X+Y-Z grows away from the
+// viewer. Unit tiles on the lattice never cross each other, so wherever two
+// of them overlap on screen one is entirely in front. Tiles are drawn back
+// to front in a topological order of that relation, which also holds for
+// touching and interpenetrating cuboids.
+
+// screenOutline returns the tile vertices in (a, b) coordinates.
+func (c *cuboidTile) screenOutline() [4]vec.Vec2i {
+	var outline [4]vec.Vec2i
+	for i, v := range c.vertices() {
+		outline[i] = vec.Vec2i{X: v.X - v.Y, Y: v.X + v.Y + 2*v.Z}
+	}
+	return outline
+}
+
+// depth2 returns twice the depth d of the tile plane at the screen point
+// (a, b).
+func (c *cuboidTile) depth2(a, b float64) float64 {
+	p := c.Position
+	switch c.Side {
+	case tileTop:
+		return 2*b - 6*float64(p.Z)
+	case tileLeft:
+		return 6*float64(p.X) - 3*a - b
+	default:
+		return 6*float64(p.Y) + 3*a - b
+	}
+}
+
+// separatingAxes are the normals of all tile edges on screen, the edges run
+// along (1,1), (-1,1) and (0,1).
+var separatingAxes = []vec.Vec2i{{X: 1, Y: -1}, {X: 1, Y: 1}, {X: 1, Y: 0}}
+
+// overlaps reports whether both outlines share a positive area, touching
+// edges don't count.
+func overlaps(a, b [4]vec.Vec2i) bool {
+	for _, axis := range separatingAxes {
+		minA, maxA := projectOnAxis(a, axis)
+		minB, maxB := projectOnAxis(b, axis)
+		if maxA <= minB || maxB <= minA {
+			return false
+		}
+	}
+	return true
+}
+
+func projectOnAxis(outline [4]vec.Vec2i, axis vec.Vec2i) (int, int) {
+	lo, hi := outline[0].Dot(axis), outline[0].Dot(axis)
+	for _, v := range outline[1:] {
+		lo = min(lo, v.Dot(axis))
+		hi = max(hi, v.Dot(axis))
+	}
+	return lo, hi
+}
+
+// overlapCenter returns a point inside the overlap of two overlapping
+// outlines, by clipping one against the other.
+func overlapCenter(a, b [4]vec.Vec2i) (float64, float64) {
+	type point struct{ x, y float64 }
+
+	poly := make([]point, 4)
+	for i, v := range a {
+		poly[i] = point{float64(v.X), float64(v.Y)}
+	}
+
+	// orientation of b, so inside is the same side for every edge
+	orientation := 1.0
+	if cross(b[0], b[1], b[2]) < 0 {
+		orientation = -1
+	}
+
+	for i := range b {
+		from, to := b[i], b[(i+1)%4]
+		side := func(p point) float64 {
+			dx, dy := float64(to.X-from.X), float64(to.Y-from.Y)
+			return orientation * ((p.x-float64(from.X))*dy - (p.y-float64(from.Y))*dx)
+		}
+
+		var clipped []point
+		for j, p := range poly {
+			q := poly[(j+1)%len(poly)]
+			sp, sq := side(p), side(q)
+			if sp >= 0 {
+				clipped = append(clipped, p)
+			}
+			if (sp < 0) != (sq < 0) {
+				t := sp / (sp - sq)
+				clipped = append(clipped, point{p.x + t*(q.x-p.x), p.y + t*(q.y-p.y)})
+			}
+		}
+		poly = clipped
+	}
+
+	var sx, sy float64
+	for _, p := range poly {
+		sx += p.x
+		sy += p.y
+	}
+	return sx / float64(len(poly)), sy / float64(len(poly))
+}
+
+// inFront reports whether a hides b where they overlap, false for coplanar
+// tiles.
+func inFront(a, b *cuboidTile, outlineA, outlineB [4]vec.Vec2i) bool {
+	x, y := overlapCenter(outlineA, outlineB)
+	return a.depth2(x, y) < b.depth2(x, y)
+}
+
+// depthOrder returns the tiles in back to front order. Tiles that don't
+// overlap are ordered by depthCmp, which is also the fallback should the
+// relation ever turn out cyclic.
+func depthOrder(tiles []*cuboidTile) []*cuboidTile {
+	outlines := make([][4]vec.Vec2i, len(tiles))
+	for i, t := range tiles {
+		outlines[i] = t.screenOutline()
+	}
+
+	// only tiles sharing a bucket of the screen can overlap, a pair is
+	// handled in the bucket holding the corner of their common bounds
+	const bucketSize = 2
+	bucketOf := func(p vec.Vec2i) vec.Vec2i {
+		return vec.Vec2i{X: vec.FloorDiv(p.X, bucketSize), Y: vec.FloorDiv(p.Y, bucketSize)}
+	}
+	bounds := make([]vec.AABB, len(tiles))
+	buckets := map[vec.Vec2i][]int{}
+	for i, outline := range outlines {
+		bounds[i] = vec.BoundingBox2i(outline[:])
+		vec.AABB{From: bucketOf(bounds[i].From), To: bucketOf(bounds[i].To)}.ForEach(func(b vec.Vec2i) {
+			buckets[b] = append(buckets[b], i)
+		})
+	}
+
+	g := graph.NewDirected[int]()
+	for i := range tiles {
+		g.AddNode(i)
+	}
+	for bucket, ids := range buckets {
+		for n, i := range ids {
+			for _, j := range ids[n+1:] {
+				if bucketOf(bounds[i].Intersect(bounds[j]).From) != bucket {
+					continue
+				}
+				if !overlaps(outlines[i], outlines[j]) {
+					continue
+				}
+				switch {
+				case inFront(tiles[i], tiles[j], outlines[i], outlines[j]):
+					g.AddEdge(j, i)
+				case inFront(tiles[j], tiles[i], outlines[j], outlines[i]):
+					g.AddEdge(i, j)
+				}
+			}
+		}
+	}
+
+	byDepth := func(i, j int) int {
+		return depthCmp(tiles[i], tiles[j])
+	}
+	order, err := g.TopoSort(byDepth)
+	if err != nil {
+		sorted := slices.Clone(tiles)
+		slices.SortStableFunc(sorted, depthCmp)
+		return sorted
+	}
+
+	sorted := make([]*cuboidTile, len(order))
+	for i, id := range order {
+		sorted[i] = tiles[id]
+	}
+	return sorted
+}
+
+// depthCmp is a rough back to front order, bottom first, then the farthest
+// diagonal.
+func depthCmp(a, b *cuboidTile) int {
+	pa := a.Position
+	pb := b.Position
+
+	if r := cmp.Compare(pa.Z, pb.Z); r != 0 {
+		return r
+	}
+	if r := cmp.Compare(pb.X+pb.Y, pa.X+pa.Y); r != 0 {
+		return r
+	}
+	if r := cmp.Compare(pa.X, pb.X); r != 0 {
+		return r
+	}
+	return cmp.Compare(a.Side, b.Side)
+}
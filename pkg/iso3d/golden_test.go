@@ -0,0 +1,101 @@
+package iso3d
+
+import (
+	"bytes"
+	"flag"
+	"image/color"
+	"image/png"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"aoc/pkg/vec"
+)
+
+var update = flag.Bool("update", false, "update golden images in testdata")
+
+// goldenScenes are rendered and compared pixel by pixel to testdata
+var goldenScenes = map[string][]*Cuboid{
+	"touching": {
+		{Position: vec.Vec3i{}, Size: vec.Vec3i{X: 2, Y: 2, Z: 1}, Color: colorPalette[0]},
+		{Position: vec.Vec3i{X: 2}, Size: vec.Vec3i{X: 1, Y: 2, Z: 2}, Color: colorPalette[1]},
+		{Position: vec.Vec3i{Z: 1}, Size: vec.Vec3i{X: 2, Y: 1, Z: 1}, Color: colorPalette[2]},
+		{Position: vec.Vec3i{Y: 2}, Size: vec.Vec3i{X: 3, Y: 1, Z: 1}, Color: colorPalette[3]},
+	},
+	"interpenetrating": {
+		{Position: vec.Vec3i{X: 0, Y: 1, Z: 0}, Size: vec.Vec3i{X: 4, Y: 1, Z: 1}, Color: colorPalette[0]},
+		{Position: vec.Vec3i{X: 1, Y: 0, Z: 0}, Size: vec.Vec3i{X: 1, Y: 4, Z: 1}, Color: colorPalette[1]},
+		{Position: vec.Vec3i{X: 1, Y: 1, Z: -1}, Size: vec.Vec3i{X: 2, Y: 2, Z: 3}, Color: colorPalette[2]},
+	},
+	"three_boxes": {
+		{Position: vec.Vec3i{X: 0, Y: 1, Z: 0}, Size: vec.Vec3i{X: 1, Y: 1, Z: 2}, Color: colorPalette[0]},
+		{Position: vec.Vec3i{X: 0, Y: 0, Z: 0}, Size: vec.Vec3i{X: 2, Y: 1, Z: 1}, Color: colorPalette[1]},
+		{Position: vec.Vec3i{X: 1, Y: 0, Z: 1}, Size: vec.Vec3i{X: 1, Y: 2, Z: 1}, Color: colorPalette[2]},
+	},
+	"stack": randomStack(),
+}
+
+// randomStack drops overlapping bricks onto each other, like a settled
+// pile of sand bricks.
+func randomStack() []*Cuboid {
+	rnd := rand.New(rand.NewSource(22))
+	var cubes []*Cuboid
+	for i := 0; i < 40; i++ {
+		cubes = append(cubes, &Cuboid{
+			Position: vec.Vec3i{X: rnd.Intn(6), Y: rnd.Intn(6), Z: rnd.Intn(8)},
+			Size:     vec.Vec3i{X: rnd.Intn(3) + 1, Y: rnd.Intn(3) + 1, Z: rnd.Intn(2) + 1},
+			Color:    colorPalette[i%len(colorPalette)],
+		})
+	}
+	return cubes
+}
+
+func TestCanvas_Golden(t *testing.T) {
+	for name, cubes := range goldenScenes {
+		t.Run(name, func(t *testing.T) {
+			canvas := NewFittedCanvas(Camera{TileWidth: 32, Margin: 4})
+			for _, c := range cubes {
+				canvas.AddCube(c)
+			}
+			canvas.Draw()
+
+			var rendered bytes.Buffer
+			if err := png.Encode(&rendered, canvas.AsImage()); err != nil {
+				t.Fatal(err)
+			}
+
+			golden := filepath.Join("testdata", name+".png")
+			if *update {
+				if err := os.WriteFile(golden, rendered.Bytes(), 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			f, err := os.Open(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			want, err := png.Decode(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := canvas.AsImage()
+			if got.Bounds() != want.Bounds() {
+				t.Fatalf("size %v, want %v", got.Bounds(), want.Bounds())
+			}
+			for y := got.Bounds().Min.Y; y < got.Bounds().Max.Y; y++ {
+				for x := got.Bounds().Min.X; x < got.Bounds().Max.X; x++ {
+					g := color.RGBAModel.Convert(got.At(x, y))
+					w := color.RGBAModel.Convert(want.At(x, y))
+					if g != w {
+						t.Fatalf("pixel (%d,%d) is %v, want %v", x, y, g, w)
+					}
+				}
+			}
+		})
+	}
+}
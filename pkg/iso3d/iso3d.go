@@ -5,13 +5,12 @@ package iso3d
 // https://stackoverflow.com/questions/892811/drawing-isometric-game-worlds
 
 import (
-	"cmp"
 	"fmt"
 	"image"
 	"image/color"
 	"image/draw"
-	"slices"
 
+	"aoc/pkg/render"
 	"aoc/pkg/vec"
 )
 
@@ -21,32 +20,25 @@ var (
 	Blue  = color.RGBA{0, 0, 255, 255}
 )
 
-const (
-	tileWidth      = 16
-	tileWidthHalf  = tileWidth / 2
-	tileHeight     = 8
-	tileHeightHalf = tileHeight / 2
-)
-
 var unitFaceLeft = []vec.Vec3i{
 	{},
-	{0, 0, 1},
-	{0, 1, 1},
-	{0, 1, 0},
+	{X: 0, Y: 0, Z: 1},
+	{X: 0, Y: 1, Z: 1},
+	{X: 0, Y: 1, Z: 0},
 }
 
 var unitFaceTop = []vec.Vec3i{
-	{0, 0, 0},
-	{1, 0, 0},
-	{1, 1, 0},
-	{0, 1, 0},
+	{X: 0, Y: 0, Z: 0},
+	{X: 1, Y: 0, Z: 0},
+	{X: 1, Y: 1, Z: 0},
+	{X: 0, Y: 1, Z: 0},
 }
 
 var unitFaceRight = []vec.Vec3i{
 	{},
-	{1, 0, 0},
-	{1, 0, 1},
-	{0, 0, 1},
+	{X: 1, Y: 0, Z: 0},
+	{X: 1, Y: 0, Z: 1},
+	{X: 0, Y: 0, Z: 1},
 }
 
 type Face struct {
@@ -65,6 +57,9 @@ type cuboidTile struct {
 	Position vec.Vec3i
 	Side     uint8
 	Color    color.RGBA
+
+	// Source is the cuboid as added to the canvas
+	Source *Cuboid
 }
 
 func (c *cuboidTile) vertices() []vec.Vec3i {
@@ -119,13 +114,18 @@ func (c *Cuboid) String() string {
 	return fmt.Sprintf("{ Position: %s, Size: %s }", c.Position, c.Size)
 }
 
+// Bounds returns the unit cells occupied by the cuboid.
+func (c *Cuboid) Bounds() vec.AABB3i {
+	return vec.NewAABB3iFromSize(c.Position, c.Size)
+}
+
 func (c *Cuboid) tileFaces() []*cuboidTile {
 	size := c.Size.Y*c.Size.Y + c.Size.X*c.Size.Z + c.Size.X*c.Size.Y
 	faces := make([]*cuboidTile, 0, size)
 
 	for y := 0; y < c.Size.Y; y++ {
 		for z := 0; z < c.Size.Z; z++ {
-			origin := c.Position.Add(vec.Vec3i{0, y, z})
+			origin := c.Position.Add(vec.Vec3i{X: 0, Y: y, Z: z})
 			faces = append(faces, &cuboidTile{
 				Position: origin,
 				Side:     tileLeft,
@@ -134,26 +134,24 @@ func (c *Cuboid) tileFaces() []*cuboidTile {
 		}
 	}
 
-	rightColor := mulColor(c.Color, 0.8)
 	for x := 0; x < c.Size.X; x++ {
 		for z := 0; z < c.Size.Z; z++ {
-			origin := c.Position.Add(vec.Vec3i{x, 0, z})
+			origin := c.Position.Add(vec.Vec3i{X: x, Y: 0, Z: z})
 			faces = append(faces, &cuboidTile{
 				Position: origin,
 				Side:     tileRight,
-				Color:    rightColor,
+				Color:    c.Color,
 			})
 		}
 	}
 
-	topColor := mulColor(c.Color, 1.2)
 	for x := 0; x < c.Size.X; x++ {
 		for y := 0; y < c.Size.Y; y++ {
-			origin := c.Position.Add(vec.Vec3i{x, y, c.Size.Z})
+			origin := c.Position.Add(vec.Vec3i{X: x, Y: y, Z: c.Size.Z})
 			faces = append(faces, &cuboidTile{
 				Position: origin,
 				Side:     tileTop,
-				Color:    topColor,
+				Color:    c.Color,
 			})
 		}
 	}
@@ -179,38 +177,117 @@ func clamp(v float32) uint8 {
 }
 
 type Canvas struct {
-	img           *image.RGBA
-	width, height int
+	img *image.RGBA
+	cam Camera
+
+	// origin is where the projection of the origin ends up on the image
+	origin vec.Vec2i
+	size   vec.Vec2i
+	fit    bool
 
-	faces []*cuboidTile
+	// floor is the footprint of the floor grid in unit cells
+	floor  vec.AABB
+	faces  []*cuboidTile
+	sorted bool
+	labels []label
+
+	Options RenderOptions
 }
 
+// NewCanvas creates a canvas of a fixed size with the origin at the bottom
+// center and a 16x16 floor.
 func NewCanvas(width, height int) *Canvas {
-	img := image.NewRGBA(image.Rect(0, 0, width, height))
-	draw.Draw(img, img.Bounds(), &image.Uniform{color.White}, image.Point{}, draw.Src)
-
 	c := &Canvas{
-		img:    img,
-		width:  width,
-		height: height,
+		cam:    DefaultCamera,
+		origin: vec.Vec2i{X: width / 2, Y: height},
+		size:   vec.Vec2i{X: width, Y: height},
+		floor:  vec.AABB{To: vec.Vec2i{X: 15, Y: 15}},
 	}
-
+	c.clear()
 	c.drawFloor()
 
 	return c
 }
 
+// NewFittedCanvas creates a canvas that sizes itself to the scene on Draw,
+// keeping the camera margin free around it. The floor covers the footprint
+// of the scene.
+func NewFittedCanvas(cam Camera) *Canvas {
+	return &Canvas{
+		cam:   cam,
+		fit:   true,
+		floor: vec.AABB{From: vec.Vec2i{X: 1, Y: 1}},
+	}
+}
+
+func (c *Canvas) clear() {
+	c.img = image.NewRGBA(image.Rect(0, 0, c.size.X, c.size.Y))
+	draw.Draw(c.img, c.img.Bounds(), &image.Uniform{color.White}, image.Point{}, draw.Src)
+}
+
+// AsImage returns the rendered image, for a fitted canvas only after Draw.
 func (c *Canvas) AsImage() image.Image {
 	return c.img
 }
 
 func (c *Canvas) AddCube(cube *Cuboid) {
+	source := cube
+	cube = c.cam.orient(cube)
 	faces := cube.tileFaces()
+	for _, f := range faces {
+		f.Source = source
+	}
 
 	c.faces = append(c.faces, faces...)
+	c.sorted = false
+
+	if c.fit {
+		footprint := cube.Bounds()
+		c.floor = c.floor.Union(vec.AABB{
+			From: vec.Vec2i{X: footprint.From.X, Y: footprint.From.Y},
+			To:   vec.Vec2i{X: footprint.To.X, Y: footprint.To.Y},
+		})
+	}
+}
+
+// sceneBounds returns the projected bounding box of all faces and the floor.
+func (c *Canvas) sceneBounds() vec.AABB {
+	var points []vec.Vec2i
+	for _, f := range c.faces {
+		for _, vertex := range f.vertices() {
+			points = append(points, c.cam.project(vertex))
+		}
+	}
+	for _, corner := range c.floorCorners() {
+		points = append(points, c.cam.project(corner))
+	}
+	for _, p := range c.overlayPoints() {
+		points = append(points, c.cam.project(p))
+	}
+	if len(points) == 0 {
+		points = append(points, vec.Vec2i{})
+	}
+	return vec.BoundingBox2i(points)
+}
+
+// layout sizes the canvas to the projected bounds plus the camera margin.
+func (c *Canvas) layout(bounds vec.AABB) {
+	margin := c.cam.Margin
+	c.origin = vec.Vec2i{X: margin - bounds.From.X, Y: margin + bounds.To.Y}
+	c.size = bounds.Size().Add(vec.Vec2i{X: 2 * margin, Y: 2 * margin})
 }
 
 func (c *Canvas) Draw() {
+	if c.fit {
+		c.layout(c.sceneBounds())
+		c.clear()
+		c.drawFloor()
+	}
+	c.drawFaces()
+	c.drawOverlays()
+}
+
+func (c *Canvas) drawFaces() {
 	c.depthSortFaces()
 
 	projectedFace := make([]vec.Vec2i, 4)
@@ -218,68 +295,60 @@ func (c *Canvas) Draw() {
 		for i, vertex := range f.vertices() {
 			projectedFace[i] = c.isoProject(vertex)
 		}
-		drawSquare(c.img, projectedFace, f.Color)
+		c.fillFace(projectedFace, c.Options.faceColor(f))
+		if c.Options.Outline != nil {
+			c.drawOutline(projectedFace)
+		}
 	}
 }
 
 func (c *Canvas) depthSortFaces() {
-	slices.SortFunc(c.faces, depthCmp)
-}
-
-func depthCmp(a, b *cuboidTile) int {
-	pa := a.Position
-	pb := b.Position
-
-	// draw bottom first
-	r := cmp.Compare(pa.Z, pb.Z)
-	if r != 0 {
-		return r
-	}
-
-	// last diagonal first
-	r = cmp.Compare(pb.X+pb.Y, pa.X+pa.Y)
-	if r != 0 {
-		return r
+	if !c.sorted {
+		c.faces = depthOrder(c.faces)
+		c.sorted = true
 	}
+}
 
-	r = cmp.Compare(pa.X, pb.X)
-	if r != 0 {
-		return r
-	}
+func (c *Canvas) isoProject(p vec.Vec3i) vec.Vec2i {
+	projected := c.cam.project(p)
+	return vec.Vec2i{X: projected.X + c.origin.X, Y: c.origin.Y - projected.Y}
+}
 
-	// same position
-	if pa != pb {
-		panic(fmt.Errorf("expected %s == %s", a.Position, b.Position))
+// floorCorners returns the outer corners of the floor grid, which runs
+// along the cell edges.
+func (c *Canvas) floorCorners() []vec.Vec3i {
+	if c.floor.IsEmpty() {
+		return nil
 	}
-
-	if a.Side == b.Side {
-		panic(fmt.Errorf("overlapping face: %s / %s", a, b))
+	from, to := c.floor.From, c.floor.To.Add(vec.Vec2i{X: 1, Y: 1})
+	return []vec.Vec3i{
+		{X: from.X, Y: from.Y},
+		{X: to.X, Y: from.Y},
+		{X: to.X, Y: to.Y},
+		{X: from.X, Y: to.Y},
 	}
-
-	return cmp.Compare(a.Side, b.Side)
-}
-
-func (c *Canvas) isoProject(p vec.Vec3i) vec.Vec2i {
-	projected := project(p)
-	return vec.Vec2i{projected.X + c.width/2, c.height - projected.Y}
 }
 
 func (c *Canvas) drawFloor() {
-	length := 16
+	if c.floor.IsEmpty() {
+		return
+	}
+	from, to := c.floor.From, c.floor.To.Add(vec.Vec2i{X: 1, Y: 1})
 
-	for i := 0; i <= length; i++ {
-		start := c.isoProject(vec.Vec3i{0, i, 0})
-		end := c.isoProject(vec.Vec3i{length, i, 0})
+	for y := from.Y; y <= to.Y; y++ {
+		start := c.isoProject(vec.Vec3i{X: from.X, Y: y})
+		end := c.isoProject(vec.Vec3i{X: to.X, Y: y})
 		c.drawLine(start, end)
-
-		start = c.isoProject(vec.Vec3i{i, 0, 0})
-		end = c.isoProject(vec.Vec3i{i, length, 0})
+	}
+	for x := from.X; x <= to.X; x++ {
+		start := c.isoProject(vec.Vec3i{X: x, Y: from.Y})
+		end := c.isoProject(vec.Vec3i{X: x, Y: to.Y})
 		c.drawLine(start, end)
 	}
 }
 
 func (c *Canvas) drawLine(start, end vec.Vec2i) {
-	drawLine(c.img, start, end, color.Black)
+	render.Line(c.img, start, end, color.Black)
 }
 
 func drawIsoCube(rgba *image.RGBA, cube *Cuboid, c color.Color) {
@@ -295,34 +364,31 @@ func drawIsoCube(rgba *image.RGBA, cube *Cuboid, c color.Color) {
 }
 
 func project(p vec.Vec3i) vec.Vec2i {
-	x := (p.X-p.Y)*tileWidthHalf - tileWidthHalf
-	y := (p.X + p.Y) * tileHeightHalf
-	y += p.Z * tileHeight
-	return vec.Vec2i{x, y}
+	return DefaultCamera.project(p)
 }
 
 func drawCoordinate(rgba *image.RGBA, origin vec.Vec3i) {
-	xUnity := project(vec.Vec3i{1, 0, 0})
-	yUnity := project(vec.Vec3i{0, 1, 0})
-	zUnity := project(vec.Vec3i{0, 0, 1})
+	xUnity := project(vec.Vec3i{X: 1, Y: 0, Z: 0})
+	yUnity := project(vec.Vec3i{X: 0, Y: 1, Z: 0})
+	zUnity := project(vec.Vec3i{X: 0, Y: 0, Z: 1})
 
 	p := project(origin)
 	rgba.Set(p.X, p.Y, color.Black)
 
-	p = project(origin.Add(vec.Vec3i{1, 0, 0}))
+	p = project(origin.Add(vec.Vec3i{X: 1, Y: 0, Z: 0}))
 	rgba.Set(xUnity.X, xUnity.Y, Red)
 
-	p = project(origin.Add(vec.Vec3i{0, 1, 0}))
+	p = project(origin.Add(vec.Vec3i{X: 0, Y: 1, Z: 0}))
 	rgba.Set(yUnity.X, yUnity.Y, Blue)
 
-	p = project(origin.Add(vec.Vec3i{0, 0, 1}))
+	p = project(origin.Add(vec.Vec3i{X: 0, Y: 0, Z: 1}))
 	rgba.Set(zUnity.X, zUnity.Y, Green)
 }
 
 func drawUnitCube(rgba *image.RGBA, pos vec.Vec3i) {
 	cube := &Cuboid{
 		Position: pos,
-		Size:     vec.Vec3i{1, 1, 1},
+		Size:     vec.Vec3i{X: 1, Y: 1, Z: 1},
 	}
 	faces := cube.tileFaces()
 
@@ -337,11 +403,18 @@ func drawUnitCube(rgba *image.RGBA, pos vec.Vec3i) {
 }
 
 func drawSquare(img *image.RGBA, vertices []vec.Vec2i, c color.Color) {
+	fillSquare(vertices, func(x, y int) {
+		img.Set(x, y, c)
+	})
+}
+
+// fillSquare calls plot for every pixel within the convex quad.
+func fillSquare(vertices []vec.Vec2i, plot func(x, y int)) {
 	bb := vec.BoundingBox2i(vertices)
 
 	for x := bb.From.X; x <= bb.To.X; x++ {
 		for y := bb.From.Y; y <= bb.To.Y; y++ {
-			p := vec.Vec2i{x, y}
+			p := vec.Vec2i{X: x, Y: y}
 
 			c1 := cross(vertices[0], vertices[1], p)
 			if c1 < 0 {
@@ -360,11 +433,7 @@ func drawSquare(img *image.RGBA, vertices []vec.Vec2i, c color.Color) {
 				continue
 			}
 
-			//if c1 == 0 || c2 == 0 || c3 == 0 || c4 == 0 {
-			//	img.Set(x, y, color.Black)
-			//} else {
-			img.Set(x, y, c)
-			//}
+			plot(x, y)
 		}
 	}
 }
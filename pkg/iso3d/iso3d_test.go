@@ -11,6 +11,7 @@ import (
 	"strings"
 	"testing"
 
+	"aoc/pkg/be"
 	"aoc/pkg/util"
 	"aoc/pkg/vec"
 )
@@ -165,3 +166,30 @@ func hexDecode(s string) color.RGBA {
 	b := util.Must(hex.DecodeString(s[4:6]))
 	return color.RGBA{r[0], g[0], b[0], 0xff}
 }
+
+func TestFittedCanvas(t *testing.T) {
+	canvas := NewFittedCanvas(Camera{TileWidth: 8, Margin: 4})
+	canvas.AddCube(&Cuboid{
+		Position: vec.Vec3i{X: 10, Y: 20, Z: 5},
+		Size:     vec.Vec3i{X: 2, Y: 3, Z: 4},
+		Color:    Red,
+	})
+	canvas.Draw()
+
+	img := canvas.AsImage()
+	be.Equal(t, img.Bounds().Size(), image.Point{X: 29, Y: 55})
+	be.Equal(t, color.RGBAModel.Convert(img.At(0, 0)), color.Color(color.RGBA{R: 255, G: 255, B: 255, A: 255}))
+}
+
+func TestCamera_Orient(t *testing.T) {
+	cube := &Cuboid{Size: vec.Vec3i{X: 2, Y: 3, Z: 1}, Color: Red}
+
+	oriented := Camera{Corner: CornerMaxXMaxY}.orient(cube)
+	be.Equal(t, oriented.Position, vec.Vec3i{X: -1, Y: -2})
+	be.Equal(t, oriented.Size, cube.Size)
+
+	oriented = Camera{Corner: CornerMaxXMinY}.orient(cube)
+	be.Equal(t, oriented.Position, vec.Vec3i{X: 0, Y: -1})
+	be.Equal(t, oriented.Size, vec.Vec3i{X: 3, Y: 2, Z: 1})
+	be.Equal(t, oriented.Color, Red)
+}
@@ -0,0 +1,278 @@
+package iso3d
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/binary"
+	"fmt"
+	"image/color"
+	"io"
+	"math"
+	"slices"
+
+	"aoc/pkg/sets"
+	"aoc/pkg/vec"
+)
+
+// Quad is an axis aligned rectangle of a mesh surface. Its vertices run
+// counterclockwise when looking at it from outside, against Normal.
+type Quad struct {
+	Vertices [4]vec.Vec3i
+	Normal   vec.Vec3i
+	Color    color.RGBA
+}
+
+// Mesh is the outer surface of a set of unit voxels. Faces between two
+// voxels are dropped and neighbouring coplanar faces of the same colour are
+// merged into larger quads.
+type Mesh struct {
+	Quads []Quad
+}
+
+var meshGray = color.RGBA{R: 160, G: 160, B: 160, A: 255}
+
+// NewMeshFromCuboids builds the surface of all cuboids, where they overlap
+// the colour of the one added last wins.
+func NewMeshFromCuboids(cubes []*Cuboid) *Mesh {
+	voxels := map[vec.Vec3i]color.RGBA{}
+	for _, c := range cubes {
+		c.Bounds().ForEach(func(p vec.Vec3i) {
+			voxels[p] = c.Color
+		})
+	}
+	return newMesh(voxels)
+}
+
+// NewMeshFromVoxels builds the surface of the voxels, coloured by colorOf
+// or gray if it is nil.
+func NewMeshFromVoxels(voxels sets.Set[vec.Vec3i], colorOf func(p vec.Vec3i) color.RGBA) *Mesh {
+	colored := make(map[vec.Vec3i]color.RGBA, voxels.Size())
+	for p := range voxels {
+		c := meshGray
+		if colorOf != nil {
+			c = colorOf(p)
+		}
+		colored[p] = c
+	}
+	return newMesh(colored)
+}
+
+func toArray(v vec.Vec3i) [3]int {
+	return [3]int{v.X, v.Y, v.Z}
+}
+
+func fromArray(a [3]int) vec.Vec3i {
+	return vec.Vec3i{X: a[0], Y: a[1], Z: a[2]}
+}
+
+// faceCell is a unit face within a layer, by its coordinates along the two
+// other axes.
+type faceCell struct{ u, v int }
+
+// layerKey identifies the plane a face lies in, by axis, its side and the
+// coordinate of the voxel.
+type layerKey struct {
+	axis, sign, coord int
+}
+
+func newMesh(voxels map[vec.Vec3i]color.RGBA) *Mesh {
+	layers := map[layerKey]map[faceCell]color.RGBA{}
+	for p, c := range voxels {
+		pa := toArray(p)
+		for axis := 0; axis < 3; axis++ {
+			for _, sign := range []int{-1, 1} {
+				n := pa
+				n[axis] += sign
+				if _, ok := voxels[fromArray(n)]; ok {
+					continue
+				}
+				key := layerKey{axis: axis, sign: sign, coord: pa[axis]}
+				if layers[key] == nil {
+					layers[key] = map[faceCell]color.RGBA{}
+				}
+				layers[key][faceCell{u: pa[(axis+1)%3], v: pa[(axis+2)%3]}] = c
+			}
+		}
+	}
+
+	keys := make([]layerKey, 0, len(layers))
+	for k := range layers {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, func(a, b layerKey) int {
+		if r := cmp.Compare(a.axis, b.axis); r != 0 {
+			return r
+		}
+		if r := cmp.Compare(a.sign, b.sign); r != 0 {
+			return r
+		}
+		return cmp.Compare(a.coord, b.coord)
+	})
+
+	m := &Mesh{}
+	for _, k := range keys {
+		m.Quads = append(m.Quads, mergeLayer(k, layers[k])...)
+	}
+	return m
+}
+
+// mergeLayer greedily covers the faces of a layer with rectangles of one
+// colour, growing each along u first and then along v.
+func mergeLayer(k layerKey, faces map[faceCell]color.RGBA) []Quad {
+	cells := make([]faceCell, 0, len(faces))
+	for f := range faces {
+		cells = append(cells, f)
+	}
+	slices.SortFunc(cells, func(a, b faceCell) int {
+		if r := cmp.Compare(a.v, b.v); r != 0 {
+			return r
+		}
+		return cmp.Compare(a.u, b.u)
+	})
+
+	done := map[faceCell]bool{}
+	free := func(f faceCell, c color.RGBA) bool {
+		fc, ok := faces[f]
+		return ok && fc == c && !done[f]
+	}
+
+	var quads []Quad
+	for _, start := range cells {
+		if done[start] {
+			continue
+		}
+		c := faces[start]
+
+		width := 1
+		for free(faceCell{u: start.u + width, v: start.v}, c) {
+			width++
+		}
+		height := 1
+	grow:
+		for {
+			for du := 0; du < width; du++ {
+				if !free(faceCell{u: start.u + du, v: start.v + height}, c) {
+					break grow
+				}
+			}
+			height++
+		}
+
+		for dv := 0; dv < height; dv++ {
+			for du := 0; du < width; du++ {
+				done[faceCell{u: start.u + du, v: start.v + dv}] = true
+			}
+		}
+		quads = append(quads, newQuad(k, start, width, height, c))
+	}
+	return quads
+}
+
+func newQuad(k layerKey, start faceCell, width, height int, c color.RGBA) Quad {
+	plane := k.coord
+	if k.sign > 0 {
+		plane++
+	}
+	u, v := (k.axis+1)%3, (k.axis+2)%3
+
+	corner := func(du, dv int) vec.Vec3i {
+		var p [3]int
+		p[k.axis] = plane
+		p[u] = start.u + du
+		p[v] = start.v + dv
+		return fromArray(p)
+	}
+
+	// u × v points along +axis, so this order is counterclockwise seen
+	// from the positive side
+	q := Quad{
+		Vertices: [4]vec.Vec3i{corner(0, 0), corner(width, 0), corner(width, height), corner(0, height)},
+		Color:    c,
+	}
+	var n [3]int
+	n[k.axis] = k.sign
+	q.Normal = fromArray(n)
+	if k.sign < 0 {
+		slices.Reverse(q.Vertices[:])
+	}
+	return q
+}
+
+func materialName(c color.RGBA) string {
+	return fmt.Sprintf("c%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// WriteOBJ writes the mesh as Wavefront OBJ referencing the material
+// library mtlName, and that library with a material per colour to mtl.
+func (m *Mesh) WriteOBJ(obj, mtl io.Writer, mtlName string) error {
+	out := bufio.NewWriter(obj)
+	fmt.Fprintf(out, "mtllib %s\n", mtlName)
+
+	vertices := map[vec.Vec3i]int{}
+	normals := map[vec.Vec3i]int{}
+	index := func(ids map[vec.Vec3i]int, p vec.Vec3i, kind string) int {
+		if id, ok := ids[p]; ok {
+			return id
+		}
+		ids[p] = len(ids) + 1
+		fmt.Fprintf(out, "%s %d %d %d\n", kind, p.X, p.Y, p.Z)
+		return ids[p]
+	}
+
+	var materials []color.RGBA
+	current := ""
+	for _, q := range m.Quads {
+		var ids [4]int
+		for i, p := range q.Vertices {
+			ids[i] = index(vertices, p, "v")
+		}
+		n := index(normals, q.Normal, "vn")
+
+		if name := materialName(q.Color); name != current {
+			if !slices.Contains(materials, q.Color) {
+				materials = append(materials, q.Color)
+			}
+			current = name
+			fmt.Fprintf(out, "usemtl %s\n", name)
+		}
+		fmt.Fprintf(out, "f %d//%d %d//%d %d//%d %d//%d\n", ids[0], n, ids[1], n, ids[2], n, ids[3], n)
+	}
+	if err := out.Flush(); err != nil {
+		return err
+	}
+
+	lib := bufio.NewWriter(mtl)
+	for _, c := range materials {
+		fmt.Fprintf(lib, "newmtl %s\nKd %.4f %.4f %.4f\n\n", materialName(c),
+			float64(c.R)/255, float64(c.G)/255, float64(c.B)/255)
+	}
+	return lib.Flush()
+}
+
+// WriteSTL writes the mesh as binary STL with two triangles per quad. STL
+// has no colours.
+func (m *Mesh) WriteSTL(w io.Writer) error {
+	out := bufio.NewWriter(w)
+
+	var header [80]byte
+	copy(header[:], "aoc iso3d mesh")
+	out.Write(header[:])
+	binary.Write(out, binary.LittleEndian, uint32(2*len(m.Quads)))
+
+	var buf [50]byte
+	putVec := func(offset int, v vec.Vec3i) {
+		binary.LittleEndian.PutUint32(buf[offset:], math.Float32bits(float32(v.X)))
+		binary.LittleEndian.PutUint32(buf[offset+4:], math.Float32bits(float32(v.Y)))
+		binary.LittleEndian.PutUint32(buf[offset+8:], math.Float32bits(float32(v.Z)))
+	}
+	for _, q := range m.Quads {
+		for _, tri := range [2][3]int{{0, 1, 2}, {0, 2, 3}} {
+			putVec(0, q.Normal)
+			for i, vi := range tri {
+				putVec(12+12*i, q.Vertices[vi])
+			}
+			out.Write(buf[:])
+		}
+	}
+	return out.Flush()
+}
@@ -0,0 +1,67 @@
+package iso3d
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"aoc/pkg/be"
+	"aoc/pkg/sets"
+	"aoc/pkg/vec"
+)
+
+func TestMesh_Merge(t *testing.T) {
+	// a brick of same coloured voxels is a box of six quads
+	brick := NewMeshFromCuboids([]*Cuboid{
+		{Size: vec.Vec3i{X: 3, Y: 2, Z: 1}, Color: Red},
+	})
+	be.Equal(t, len(brick.Quads), 6)
+
+	voxels := sets.New[vec.Vec3i]()
+	voxels.PutAll([]vec.Vec3i{{X: 0}, {X: 1}, {X: 2}, {X: 1, Z: 1}})
+	be.Equal(t, len(NewMeshFromVoxels(voxels, nil).Quads), 12)
+}
+
+func TestMesh_SharedFaces(t *testing.T) {
+	// touching bricks of different colours drop the faces in between
+	mesh := NewMeshFromCuboids([]*Cuboid{
+		{Size: vec.Vec3i{X: 1, Y: 1, Z: 1}, Color: Red},
+		{Position: vec.Vec3i{X: 1}, Size: vec.Vec3i{X: 1, Y: 1, Z: 1}, Color: Blue},
+	})
+	be.Equal(t, len(mesh.Quads), 10)
+
+	for _, q := range mesh.Quads {
+		// counterclockwise seen from outside
+		e1 := q.Vertices[1].Sub(q.Vertices[0])
+		e2 := q.Vertices[2].Sub(q.Vertices[1])
+		be.Equal(t, e1.Cross(e2).Sign(), q.Normal)
+	}
+}
+
+func TestMesh_WriteOBJ(t *testing.T) {
+	mesh := NewMeshFromCuboids([]*Cuboid{
+		{Size: vec.Vec3i{X: 1, Y: 1, Z: 1}, Color: Red},
+		{Position: vec.Vec3i{Z: 1}, Size: vec.Vec3i{X: 1, Y: 1, Z: 1}, Color: Green},
+	})
+
+	var obj, mtl bytes.Buffer
+	be.NoError(t, mesh.WriteOBJ(&obj, &mtl, "scene.mtl"))
+
+	be.True(t, strings.HasPrefix(obj.String(), "mtllib scene.mtl\n"))
+	be.Equal(t, strings.Count(obj.String(), "\nv "), 12)
+	be.Equal(t, strings.Count(obj.String(), "\nvn "), 6)
+	be.Equal(t, strings.Count(obj.String(), "\nf "), 10)
+	be.Equal(t, strings.Count(mtl.String(), "newmtl "), 2)
+	be.True(t, strings.Contains(mtl.String(), "newmtl cff0000\nKd 1.0000 0.0000 0.0000\n"))
+}
+
+func TestMesh_WriteSTL(t *testing.T) {
+	mesh := NewMeshFromCuboids([]*Cuboid{{Size: vec.Vec3i{X: 2, Y: 2, Z: 2}}})
+
+	var stl bytes.Buffer
+	be.NoError(t, mesh.WriteSTL(&stl))
+
+	be.Equal(t, stl.Len(), 84+12*50)
+	be.Equal(t, binary.LittleEndian.Uint32(stl.Bytes()[80:]), 12)
+}
@@ -0,0 +1,182 @@
+package iso3d
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"aoc/pkg/render"
+	"aoc/pkg/vec"
+)
+
+// RenderOptions controls the look of a canvas. The zero value shades the
+// sides by fixed factors and draws neither outlines nor overlays.
+type RenderOptions struct {
+	// Top, Left and Right are the brightness factors of the sides, zero
+	// values default to 1.2, 1 and 0.8.
+	Top, Left, Right float32
+
+	// Light is the direction towards a light source in view coordinates,
+	// i.e. the viewer looks from -X, -Y and +Z. If set, it replaces the
+	// brightness factors and each side is lit by its angle to the light.
+	Light vec.Vec3i
+
+	// Outline is the colour of the face edges, nil draws none.
+	Outline color.Color
+
+	// OutlineWidth is the width of the outline in pixels, defaults to 1.
+	// SVG strokes may be thinner than a pixel, drawn outlines are rounded
+	// to whole pixels.
+	OutlineWidth float64
+
+	// Transparency blends faces over what is behind them, from 0 for
+	// opaque to 1 for invisible.
+	Transparency float64
+
+	// Gizmo draws the X, Y and Z axes at the origin in red, blue and green.
+	Gizmo bool
+
+	// LabelColor is the colour of labels, defaults to black.
+	LabelColor color.Color
+}
+
+var defaultShades = [...]float32{
+	tileTop:   1.2,
+	tileLeft:  1,
+	tileRight: 0.8,
+}
+
+// sideNormals point out of the visible sides in view coordinates.
+var sideNormals = [...]vec.Vec3i{
+	tileTop:   {Z: 1},
+	tileLeft:  {X: -1},
+	tileRight: {Y: -1},
+}
+
+// shade returns the brightness factor of a side.
+func (o *RenderOptions) shade(side uint8) float32 {
+	if o.Light != (vec.Vec3i{}) {
+		cos := float64(sideNormals[side].Dot(o.Light)) / math.Sqrt(float64(o.Light.Dot(o.Light)))
+		return float32(0.4 + 0.8*max(0, cos))
+	}
+
+	shades := [...]float32{tileTop: o.Top, tileLeft: o.Left, tileRight: o.Right}
+	if shades[side] == 0 {
+		return defaultShades[side]
+	}
+	return shades[side]
+}
+
+func (o *RenderOptions) faceColor(f *cuboidTile) color.RGBA {
+	factor := o.shade(f.Side)
+	if factor == 1 {
+		return f.Color
+	}
+	return mulColor(f.Color, factor)
+}
+
+func (o *RenderOptions) outlineWidth() float64 {
+	if o.OutlineWidth <= 0 {
+		return 1
+	}
+	return o.OutlineWidth
+}
+
+func (o *RenderOptions) opacity() float64 {
+	return 1 - min(1, max(0, o.Transparency))
+}
+
+func (c *Canvas) fillFace(vertices []vec.Vec2i, col color.RGBA) {
+	alpha := c.Options.opacity()
+	if alpha == 1 {
+		drawSquare(c.img, vertices, col)
+		return
+	}
+	fillSquare(vertices, func(x, y int) {
+		if !(image.Point{X: x, Y: y}).In(c.img.Rect) {
+			return
+		}
+		i := c.img.PixOffset(x, y)
+		pix := c.img.Pix[i : i+3 : i+3]
+		for ch, v := range []uint8{col.R, col.G, col.B} {
+			pix[ch] = uint8(math.Round(alpha*float64(v) + (1-alpha)*float64(pix[ch])))
+		}
+	})
+}
+
+func (c *Canvas) drawOutline(vertices []vec.Vec2i) {
+	width := max(1, int(math.Round(c.Options.outlineWidth())))
+	for i := range vertices {
+		start, end := vertices[i], vertices[(i+1)%len(vertices)]
+		for dy := 0; dy < width; dy++ {
+			for dx := 0; dx < width; dx++ {
+				d := vec.Vec2i{X: dx - (width-1)/2, Y: dy - (width-1)/2}
+				render.Line(c.img, start.Add(d), end.Add(d), c.Options.Outline)
+			}
+		}
+	}
+}
+
+// orientPoint turns a lattice point like orient turns cells, which rotates
+// around the center of the cell at the origin.
+func (cam Camera) orientPoint(p vec.Vec3i) vec.Vec3i {
+	half := vec.Vec3i{X: 1, Y: 1}
+	doubled := cornerRotations[cam.Corner].Mul(p.Scale(2).Sub(half)).Add(half)
+	return vec.Vec3i{X: doubled.X / 2, Y: doubled.Y / 2, Z: doubled.Z / 2}
+}
+
+type label struct {
+	at   vec.Vec3i
+	text string
+}
+
+// AddLabel writes text centered on the projection of the point at, on top
+// of the scene. The built-in font has digits, letters and some punctuation.
+func (c *Canvas) AddLabel(at vec.Vec3i, text string) {
+	c.labels = append(c.labels, label{at: c.cam.orientPoint(at), text: text})
+}
+
+// gizmoAxes are the scene axes drawn by the gizmo, with their colours.
+var gizmoAxes = []struct {
+	axis vec.Vec3i
+	col  color.RGBA
+}{
+	{vec.Vec3i{X: 2}, Red},
+	{vec.Vec3i{Y: 2}, Blue},
+	{vec.Vec3i{Z: 2}, Green},
+}
+
+// overlayPoints returns the anchors of everything drawn on top of the
+// scene, so that fitting keeps them in view.
+func (c *Canvas) overlayPoints() []vec.Vec3i {
+	var points []vec.Vec3i
+	if c.Options.Gizmo {
+		points = append(points, c.cam.orientPoint(vec.Vec3i{}))
+		for _, g := range gizmoAxes {
+			points = append(points, c.cam.orientPoint(g.axis))
+		}
+	}
+	for _, l := range c.labels {
+		points = append(points, l.at)
+	}
+	return points
+}
+
+func (c *Canvas) drawOverlays() {
+	if c.Options.Gizmo {
+		origin := c.isoProject(c.cam.orientPoint(vec.Vec3i{}))
+		for _, g := range gizmoAxes {
+			end := c.isoProject(c.cam.orientPoint(g.axis))
+			render.Line(c.img, origin, end, g.col)
+		}
+	}
+
+	labelColor := c.Options.LabelColor
+	if labelColor == nil {
+		labelColor = color.Black
+	}
+	scale := max(1, c.cam.TileWidth/16)
+	for _, l := range c.labels {
+		render.Text(c.img, c.isoProject(l.at), l.text, scale, labelColor)
+	}
+}
@@ -0,0 +1,118 @@
+package iso3d
+
+import (
+	"image/color"
+	"testing"
+
+	"aoc/pkg/be"
+	"aoc/pkg/vec"
+)
+
+var unitCube = vec.Vec3i{X: 1, Y: 1, Z: 1}
+
+// countColor counts the pixels of the canvas that have exactly colour col.
+func countColor(c *Canvas, col color.Color) int {
+	want := color.RGBAModel.Convert(col)
+	count := 0
+	bounds := c.img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if c.img.RGBAAt(x, y) == want {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// topCenter returns the pixel in the middle of the top face of the cell at p.
+func topCenter(c *Canvas, p vec.Vec3i) vec.Vec2i {
+	cell := c.cam.orient(&Cuboid{Position: p, Size: unitCube})
+	top := cuboidTile{Position: cell.Position.Add(vec.Vec3i{Z: 1}), Side: tileTop}
+	var sum vec.Vec2i
+	for _, vertex := range top.vertices() {
+		sum = sum.Add(c.isoProject(vertex))
+	}
+	return vec.Vec2i{X: sum.X / 4, Y: sum.Y / 4}
+}
+
+func TestRenderOptions_shade(t *testing.T) {
+	var opts RenderOptions
+	be.Equal(t, opts.shade(tileTop), float32(1.2))
+	be.Equal(t, opts.shade(tileLeft), float32(1))
+	be.Equal(t, opts.shade(tileRight), float32(0.8))
+
+	opts.Left = 0.5
+	be.Equal(t, opts.shade(tileLeft), float32(0.5))
+	be.Equal(t, opts.shade(tileTop), float32(1.2))
+
+	// light from straight above leaves the sides in ambient light
+	opts = RenderOptions{Light: vec.Vec3i{Z: 3}}
+	be.Equal(t, opts.shade(tileTop), float32(1.2))
+	be.Equal(t, opts.shade(tileLeft), float32(0.4))
+	be.Equal(t, opts.shade(tileRight), float32(0.4))
+
+	// light from the viewer's left
+	opts = RenderOptions{Light: vec.Vec3i{X: -1}}
+	be.Equal(t, opts.shade(tileLeft), float32(1.2))
+	be.Equal(t, opts.shade(tileTop), float32(0.4))
+}
+
+func TestCanvas_Outline(t *testing.T) {
+	gray := color.RGBA{128, 128, 128, 255}
+	render := func(opts RenderOptions) *Canvas {
+		canvas := NewFittedCanvas(DefaultCamera)
+		canvas.Options = opts
+		canvas.AddCube(&Cuboid{Size: vec.Vec3i{X: 2, Y: 2, Z: 2}, Color: gray})
+		canvas.Draw()
+		return canvas
+	}
+
+	plain := render(RenderOptions{})
+	be.Equal(t, countColor(plain, Blue), 0)
+
+	thin := render(RenderOptions{Outline: Blue})
+	thick := render(RenderOptions{Outline: Blue, OutlineWidth: 3})
+	be.True(t, countColor(thin, Blue) > 0)
+	be.True(t, countColor(thick, Blue) > 2*countColor(thin, Blue))
+}
+
+func TestCanvas_Transparency(t *testing.T) {
+	canvas := NewFittedCanvas(DefaultCamera)
+	canvas.Options = RenderOptions{Top: 1, Transparency: 0.5}
+	canvas.AddCube(&Cuboid{Size: unitCube, Color: Red})
+	canvas.Draw()
+
+	// half red over the white floor
+	p := topCenter(canvas, vec.Vec3i{})
+	be.Equal(t, canvas.img.RGBAAt(p.X, p.Y), color.RGBA{255, 128, 128, 255})
+
+	canvas.Options.Transparency = 0
+	canvas.Draw()
+	be.Equal(t, canvas.img.RGBAAt(p.X, p.Y), Red)
+}
+
+func TestCanvas_Gizmo(t *testing.T) {
+	gray := color.RGBA{128, 128, 128, 255}
+	for corner := CornerMinXMinY; corner <= CornerMinXMaxY; corner++ {
+		canvas := NewFittedCanvas(Camera{TileWidth: 16, Corner: corner, Margin: 8})
+		canvas.Options.Gizmo = true
+		canvas.AddCube(&Cuboid{Size: unitCube, Color: gray})
+		canvas.Draw()
+
+		be.True(t, countColor(canvas, Red) > 0)
+		be.True(t, countColor(canvas, Green) > 0)
+		be.True(t, countColor(canvas, Blue) > 0)
+	}
+}
+
+func TestCanvas_AddLabel(t *testing.T) {
+	canvas := NewFittedCanvas(DefaultCamera)
+	canvas.Options.LabelColor = Blue
+	canvas.AddCube(&Cuboid{Size: unitCube, Color: Red})
+	canvas.AddLabel(vec.Vec3i{Z: 1}, "I")
+	canvas.Draw()
+
+	// 'I' has a 3 pixel bar at the top and bottom and a 5 pixel stem
+	be.Equal(t, countColor(canvas, Blue), 11)
+}
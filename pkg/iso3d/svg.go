@@ -0,0 +1,111 @@
+package iso3d
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"image/color"
+	"io"
+
+	"aoc/pkg/render"
+	"aoc/pkg/vec"
+)
+
+// EncodeSVG writes the scene as SVG with a polygon per face, in the same
+// projection, depth order and options as Draw. Hovering a face shows its
+// cuboid.
+func (c *Canvas) EncodeSVG(w io.Writer) error {
+	if c.fit {
+		c.layout(c.sceneBounds())
+	}
+	c.depthSortFaces()
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		c.size.X, c.size.Y, c.size.X, c.size.Y)
+	fmt.Fprintf(&buf, `<rect width="100%%" height="100%%" fill="white"/>`+"\n")
+
+	c.writeSVGFloor(&buf)
+
+	opts := &c.Options
+	style := ""
+	if opts.Outline != nil {
+		style = fmt.Sprintf(` stroke="%s" stroke-width="%g" stroke-linejoin="round"`,
+			hexColor(opts.Outline), opts.outlineWidth())
+	}
+	if opacity := opts.opacity(); opacity < 1 {
+		style += fmt.Sprintf(` fill-opacity="%g"`, opacity)
+	}
+
+	fmt.Fprintf(&buf, `<g shape-rendering="crispEdges"%s>`+"\n", style)
+	for _, f := range c.faces {
+		buf.WriteString(`<polygon points="`)
+		for i, vertex := range f.vertices() {
+			p := c.isoProject(vertex)
+			if i > 0 {
+				buf.WriteByte(' ')
+			}
+			fmt.Fprintf(&buf, "%d,%d", p.X, p.Y)
+		}
+		fmt.Fprintf(&buf, `" fill="%s">`, hexColor(opts.faceColor(f)))
+		if f.Source != nil {
+			fmt.Fprintf(&buf, "<title>%s</title>", html.EscapeString(f.Source.String()))
+		}
+		buf.WriteString("</polygon>\n")
+	}
+	buf.WriteString("</g>\n")
+
+	c.writeSVGOverlays(&buf)
+	buf.WriteString("</svg>\n")
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func (c *Canvas) writeSVGFloor(buf *bytes.Buffer) {
+	if c.floor.IsEmpty() {
+		return
+	}
+	from, to := c.floor.From, c.floor.To.Add(vec.Vec2i{X: 1, Y: 1})
+
+	line := func(start, end vec.Vec3i) {
+		s, e := c.isoProject(start), c.isoProject(end)
+		fmt.Fprintf(buf, "M%d %dL%d %d", s.X, s.Y, e.X, e.Y)
+	}
+
+	buf.WriteString(`<path stroke="black" stroke-width="1" fill="none" d="`)
+	for y := from.Y; y <= to.Y; y++ {
+		line(vec.Vec3i{X: from.X, Y: y}, vec.Vec3i{X: to.X, Y: y})
+	}
+	for x := from.X; x <= to.X; x++ {
+		line(vec.Vec3i{X: x, Y: from.Y}, vec.Vec3i{X: x, Y: to.Y})
+	}
+	buf.WriteString(`"/>` + "\n")
+}
+
+func (c *Canvas) writeSVGOverlays(buf *bytes.Buffer) {
+	if c.Options.Gizmo {
+		origin := c.isoProject(c.cam.orientPoint(vec.Vec3i{}))
+		for _, g := range gizmoAxes {
+			end := c.isoProject(c.cam.orientPoint(g.axis))
+			fmt.Fprintf(buf, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s"/>`+"\n",
+				origin.X, origin.Y, end.X, end.Y, hexColor(g.col))
+		}
+	}
+
+	labelColor := c.Options.LabelColor
+	if labelColor == nil {
+		labelColor = color.Black
+	}
+	size := render.GlyphHeight * max(1, c.cam.TileWidth/16)
+	for _, l := range c.labels {
+		p := c.isoProject(l.at)
+		fmt.Fprintf(buf, `<text x="%d" y="%d" font-family="monospace" font-size="%d" text-anchor="middle" dominant-baseline="central" fill="%s" stroke="white" stroke-width="2" paint-order="stroke">%s</text>`+"\n",
+			p.X, p.Y, size, hexColor(labelColor), html.EscapeString(l.text))
+	}
+}
+
+func hexColor(c color.Color) string {
+	rgba := color.RGBAModel.Convert(c).(color.RGBA)
+	return fmt.Sprintf("#%02x%02x%02x", rgba.R, rgba.G, rgba.B)
+}
@@ -0,0 +1,58 @@
+package iso3d
+
+import (
+	"bytes"
+	"encoding/xml"
+	"image/color"
+	"strings"
+	"testing"
+
+	"aoc/pkg/be"
+	"aoc/pkg/vec"
+)
+
+func TestCanvas_EncodeSVG(t *testing.T) {
+	canvas := NewFittedCanvas(Camera{TileWidth: 16, Margin: 2})
+	cube := &Cuboid{Size: vec.Vec3i{X: 2, Y: 1, Z: 1}, Color: color.RGBA{R: 100, G: 50, B: 200, A: 255}}
+	canvas.AddCube(cube)
+
+	var buf bytes.Buffer
+	canvas.Options.Outline = color.Black
+	be.NoError(t, canvas.EncodeSVG(&buf))
+	svg := buf.String()
+
+	// one left, two right and two top faces
+	be.Equal(t, strings.Count(svg, "<polygon"), 5)
+	be.True(t, strings.Contains(svg, `fill="#6432c8"`))
+	be.True(t, strings.Contains(svg, `stroke="#000000" stroke-width="1"`))
+	be.True(t, strings.Contains(svg, "<title>{ Position: {0,0,0}, Size: {2,1,1} }</title>"))
+
+	buf.Reset()
+	canvas.Options.OutlineWidth = 0.5
+	be.NoError(t, canvas.EncodeSVG(&buf))
+	be.True(t, strings.Contains(buf.String(), `stroke="#000000" stroke-width="0.5"`))
+
+	var doc struct {
+		Width  int `xml:"width,attr"`
+		Height int `xml:"height,attr"`
+	}
+	be.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+	be.Equal(t, doc.Width, canvas.size.X)
+	be.Equal(t, doc.Height, canvas.size.Y)
+}
+
+func TestCanvas_EncodeSVGMatchesDraw(t *testing.T) {
+	canvas := NewFittedCanvas(Camera{TileWidth: 16, Margin: 2})
+	for _, c := range goldenScenes["three_boxes"] {
+		canvas.AddCube(c)
+	}
+
+	var buf bytes.Buffer
+	be.NoError(t, canvas.EncodeSVG(&buf))
+	svgSize := canvas.size
+	canvas.Draw()
+
+	be.Equal(t, canvas.AsImage().Bounds().Size().X, svgSize.X)
+	be.Equal(t, canvas.AsImage().Bounds().Size().Y, svgSize.Y)
+	be.True(t, !strings.Contains(buf.String(), "stroke-width=\"1\" stroke-linejoin"))
+}
@@ -0,0 +1,83 @@
+package iso3d
+
+import (
+	"image/color"
+
+	"aoc/pkg/sets"
+	"aoc/pkg/vec"
+)
+
+// VoxelScene is a set of unit cubes with individual colours. Unlike adding
+// each as Cuboid, only faces not covered by a neighbouring voxel are drawn.
+type VoxelScene struct {
+	voxels sets.Set[vec.Vec3i]
+	colors map[vec.Vec3i]color.RGBA
+
+	// Color is used for voxels without a colour of their own.
+	Color color.RGBA
+}
+
+func NewVoxelScene(voxels sets.Set[vec.Vec3i]) *VoxelScene {
+	return &VoxelScene{
+		voxels: voxels,
+		colors: map[vec.Vec3i]color.RGBA{},
+		Color:  meshGray,
+	}
+}
+
+func (s *VoxelScene) Len() int {
+	return s.voxels.Size()
+}
+
+// SetColor colours the voxel at p.
+func (s *VoxelScene) SetColor(p vec.Vec3i, c color.RGBA) {
+	s.colors[p] = c
+}
+
+func (s *VoxelScene) colorOf(p vec.Vec3i) color.RGBA {
+	if c, ok := s.colors[p]; ok {
+		return c
+	}
+	return s.Color
+}
+
+// Mesh returns the outer surface of the voxels, see NewMeshFromVoxels.
+func (s *VoxelScene) Mesh() *Mesh {
+	return NewMeshFromVoxels(s.voxels, s.colorOf)
+}
+
+// AddVoxels adds the visible faces of the scene to the canvas. Faces are
+// culled after turning the scene for the camera corner, so they are the
+// ones facing the viewer.
+func (c *Canvas) AddVoxels(s *VoxelScene) {
+	rot := cornerRotations[c.cam.Corner]
+	oriented := make(map[vec.Vec3i]color.RGBA, s.Len())
+	for p := range s.voxels {
+		oriented[rot.Mul(p)] = s.colorOf(p)
+	}
+
+	sides := []struct {
+		side   uint8
+		offset vec.Vec3i
+	}{
+		{tileLeft, vec.Vec3i{X: -1}},
+		{tileRight, vec.Vec3i{Y: -1}},
+		{tileTop, vec.Vec3i{Z: 1}},
+	}
+	for p, col := range oriented {
+		for _, s := range sides {
+			if _, covered := oriented[p.Add(s.offset)]; covered {
+				continue
+			}
+			tile := &cuboidTile{Position: p, Side: s.side, Color: col}
+			if s.side == tileTop {
+				tile.Position = p.Add(s.offset)
+			}
+			c.faces = append(c.faces, tile)
+		}
+		if c.fit {
+			c.floor = c.floor.Union(vec.AABB{From: vec.Vec2i{X: p.X, Y: p.Y}, To: vec.Vec2i{X: p.X, Y: p.Y}})
+		}
+	}
+	c.sorted = false
+}
@@ -0,0 +1,82 @@
+package iso3d
+
+import (
+	"bytes"
+	"testing"
+
+	"aoc/pkg/be"
+	"aoc/pkg/sets"
+	"aoc/pkg/vec"
+)
+
+func solidVoxels(size vec.Vec3i) sets.Set[vec.Vec3i] {
+	voxels := sets.New[vec.Vec3i]()
+	vec.NewAABB3iFromSize(vec.Vec3i{}, size).ForEach(func(p vec.Vec3i) {
+		voxels.Put(p)
+	})
+	return voxels
+}
+
+func TestCanvas_AddVoxels(t *testing.T) {
+	scene := NewVoxelScene(solidVoxels(vec.Vec3i{X: 2, Y: 2, Z: 2}))
+	scene.SetColor(vec.Vec3i{}, Red)
+
+	canvas := NewFittedCanvas(DefaultCamera)
+	canvas.AddVoxels(scene)
+	be.Equal(t, len(canvas.faces), 12)
+
+	// same picture as the cuboid
+	cuboid := NewFittedCanvas(DefaultCamera)
+	cuboid.AddCube(&Cuboid{Size: vec.Vec3i{X: 2, Y: 2, Z: 2}, Color: scene.Color})
+	canvas.Draw()
+	cuboid.Draw()
+	be.Equal(t, canvas.AsImage().Bounds(), cuboid.AsImage().Bounds())
+	be.Equal(t, canvas.AsImage().At(12, 40), cuboid.AsImage().At(12, 40))
+}
+
+func TestCanvas_AddVoxelsCorner(t *testing.T) {
+	voxels := sets.New[vec.Vec3i]()
+	voxels.PutAll([]vec.Vec3i{{}, {X: 1}, {Y: 1}, {X: 1, Z: 1}, {X: 2, Y: 2}})
+	scene := NewVoxelScene(voxels)
+	scene.SetColor(vec.Vec3i{X: 1, Z: 1}, Blue)
+
+	// culled voxels look the same as unit cuboids from every corner
+	for corner := CornerMinXMinY; corner <= CornerMinXMaxY; corner++ {
+		cam := Camera{TileWidth: 16, Corner: corner}
+
+		culled := NewFittedCanvas(cam)
+		culled.AddVoxels(scene)
+		culled.Draw()
+
+		cubes := NewFittedCanvas(cam)
+		for p := range voxels {
+			cubes.AddCube(&Cuboid{Position: p, Size: vec.Vec3i{X: 1, Y: 1, Z: 1}, Color: scene.colorOf(p)})
+		}
+		cubes.Draw()
+
+		be.True(t, len(culled.faces) < len(cubes.faces))
+		be.True(t, bytes.Equal(culled.img.Pix, cubes.img.Pix))
+	}
+}
+
+// a 10k voxel scene, most voxels are hidden inside
+var benchmarkVoxels = solidVoxels(vec.Vec3i{X: 25, Y: 25, Z: 16})
+
+func BenchmarkCanvas_AddCube(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		canvas := NewFittedCanvas(DefaultCamera)
+		for p := range benchmarkVoxels {
+			canvas.AddCube(&Cuboid{Position: p, Size: vec.Vec3i{X: 1, Y: 1, Z: 1}, Color: Red})
+		}
+		canvas.Draw()
+	}
+}
+
+func BenchmarkCanvas_AddVoxels(b *testing.B) {
+	scene := NewVoxelScene(benchmarkVoxels)
+	for i := 0; i < b.N; i++ {
+		canvas := NewFittedCanvas(DefaultCamera)
+		canvas.AddVoxels(scene)
+		canvas.Draw()
+	}
+}
@@ -0,0 +1,76 @@
+package mst
+
+import (
+	"slices"
+
+	"aoc/pkg/unionfind"
+)
+
+// Connector wires up points one edge at a time, shortest edge first, and
+// keeps track of the resulting components.
+type Connector struct {
+	forest *unionfind.Dense
+	edges  []Edge
+	next   int
+}
+
+// NewConnector prepares connecting n points using the given candidate edges.
+func NewConnector(n int, edges []Edge) *Connector {
+	sorted := slices.Clone(edges)
+	slices.SortFunc(sorted, compareEdges)
+	return &Connector{
+		forest: unionfind.NewDense(n),
+		edges:  sorted,
+	}
+}
+
+// Step connects the next shortest edge. It returns the edge, whether it
+// merged two components and false once all edges are used up.
+func (c *Connector) Step() (Edge, bool, bool) {
+	if c.next >= len(c.edges) {
+		return Edge{}, false, false
+	}
+	e := c.edges[c.next]
+	c.next++
+	return e, c.forest.Union(e.A, e.B), true
+}
+
+// Connect steps through the next n edges, even those not merging anything.
+func (c *Connector) Connect(n int) {
+	for i := 0; i < n; i++ {
+		if _, _, ok := c.Step(); !ok {
+			return
+		}
+	}
+}
+
+// ConnectUntil steps until only the given number of components is left and
+// returns the edge that got there, false if the edges ran out before or
+// there were no more components to begin with.
+func (c *Connector) ConnectUntil(components int) (Edge, bool) {
+	for c.forest.Count() > components {
+		e, _, ok := c.Step()
+		if !ok {
+			return Edge{}, false
+		}
+		if c.forest.Count() <= components {
+			return e, true
+		}
+	}
+	return Edge{}, false
+}
+
+// Components returns the number of disjoint components.
+func (c *Connector) Components() int {
+	return c.forest.Count()
+}
+
+// ComponentSizes returns the size of every component.
+func (c *Connector) ComponentSizes() []int {
+	return c.forest.ComponentSizes()
+}
+
+// Connected reports whether the points a and b are in the same component.
+func (c *Connector) Connected(a, b int) bool {
+	return c.forest.Connected(a, b)
+}
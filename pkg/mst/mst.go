@@ -0,0 +1,145 @@
+package mst
+
+import (
+	"cmp"
+	"slices"
+
+	"aoc/pkg/unionfind"
+	"aoc/pkg/vec"
+)
+
+// Edge connects two points by their index in the point set.
+type Edge struct {
+	A, B     int
+	Distance int
+}
+
+// Distance is a metric between two points. For euclidean distances the
+// squared length is used, it keeps the order and stays an integer.
+type Distance[P any] func(a, b P) int
+
+func SquaredEuclidean2i(a, b vec.Vec2i) int {
+	d := a.Sub(b)
+	return d.X*d.X + d.Y*d.Y
+}
+
+func SquaredEuclidean3i(a, b vec.Vec3i) int {
+	d := a.Sub(b)
+	return d.X*d.X + d.Y*d.Y + d.Z*d.Z
+}
+
+func Manhattan2i(a, b vec.Vec2i) int {
+	return a.Sub(b).Norm1()
+}
+
+func Manhattan3i(a, b vec.Vec3i) int {
+	return a.Sub(b).Norm1()
+}
+
+func compareEdges(a, b Edge) int {
+	if r := cmp.Compare(a.Distance, b.Distance); r != 0 {
+		return r
+	}
+	if r := cmp.Compare(a.A, b.A); r != 0 {
+		return r
+	}
+	return cmp.Compare(a.B, b.B)
+}
+
+// AllPairs returns the edges between every pair of points, shortest first.
+func AllPairs[P any](points []P, dist Distance[P]) []Edge {
+	edges := make([]Edge, 0, len(points)*(len(points)-1)/2)
+	for i := range points {
+		for j := i + 1; j < len(points); j++ {
+			edges = append(edges, Edge{A: i, B: j, Distance: dist(points[i], points[j])})
+		}
+	}
+	slices.SortFunc(edges, compareEdges)
+	return edges
+}
+
+// KNearest returns the edges from every point to its k nearest neighbours,
+// shortest first. Edges found from both ends are only returned once.
+func KNearest[P any](points []P, k int, dist Distance[P]) []Edge {
+	var edges []Edge
+	candidates := make([]Edge, 0, len(points))
+	for i := range points {
+		candidates = candidates[:0]
+		for j := range points {
+			if i == j {
+				continue
+			}
+			candidates = append(candidates, Edge{A: min(i, j), B: max(i, j), Distance: dist(points[i], points[j])})
+		}
+		slices.SortFunc(candidates, compareEdges)
+		edges = append(edges, candidates[:min(k, len(candidates))]...)
+	}
+	return sortUnique(edges)
+}
+
+func sortUnique(edges []Edge) []Edge {
+	slices.SortFunc(edges, compareEdges)
+	return slices.Compact(edges)
+}
+
+// Kruskal returns the edges of a minimum spanning forest over n points.
+//
+// https://en.wikipedia.org/wiki/Kruskal%27s_algorithm
+func Kruskal(n int, edges []Edge) []Edge {
+	sorted := slices.Clone(edges)
+	slices.SortFunc(sorted, compareEdges)
+
+	forest := unionfind.NewDense(n)
+	var tree []Edge
+	for _, e := range sorted {
+		if forest.Union(e.A, e.B) {
+			tree = append(tree, e)
+			if len(tree) == n-1 {
+				break
+			}
+		}
+	}
+	return tree
+}
+
+// Prim returns the edges of a minimum spanning tree over the complete graph
+// of all points. It runs in O(n²) without materializing any edges, which
+// beats Kruskal on dense point clouds.
+//
+// https://en.wikipedia.org/wiki/Prim%27s_algorithm
+func Prim[P any](points []P, dist Distance[P]) []Edge {
+	if len(points) == 0 {
+		return nil
+	}
+
+	inTree := make([]bool, len(points))
+	best := make([]Edge, len(points))
+	for i := range best {
+		best[i] = Edge{A: 0, B: i, Distance: dist(points[0], points[i])}
+	}
+	inTree[0] = true
+
+	tree := make([]Edge, 0, len(points)-1)
+	for len(tree) < len(points)-1 {
+		next := -1
+		for i, e := range best {
+			if !inTree[i] && (next < 0 || e.Distance < best[next].Distance) {
+				next = i
+			}
+		}
+
+		e := best[next]
+		tree = append(tree, Edge{A: min(e.A, e.B), B: max(e.A, e.B), Distance: e.Distance})
+		inTree[next] = true
+
+		for i := range best {
+			if inTree[i] {
+				continue
+			}
+			if d := dist(points[next], points[i]); d < best[i].Distance {
+				best[i] = Edge{A: next, B: i, Distance: d}
+			}
+		}
+	}
+	return tree
+}
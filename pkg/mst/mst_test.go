@@ -0,0 +1,61 @@
+package mst
+
+import (
+	"math/rand"
+	"testing"
+
+	"aoc/pkg/be"
+	"aoc/pkg/vec"
+)
+
+func totalDistance(edges []Edge) int {
+	sum := 0
+	for _, e := range edges {
+		sum += e.Distance
+	}
+	return sum
+}
+
+func TestKruskalAndPrim(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1337))
+
+	var points []vec.Vec3i
+	for i := 0; i < 200; i++ {
+		points = append(points, vec.Vec3i{X: rnd.Intn(1000), Y: rnd.Intn(1000), Z: rnd.Intn(1000)})
+	}
+
+	kruskal := Kruskal(len(points), AllPairs(points, SquaredEuclidean3i))
+	prim := Prim(points, SquaredEuclidean3i)
+
+	be.Equal(t, len(kruskal), len(points)-1)
+	be.Equal(t, len(prim), len(points)-1)
+	be.Equal(t, totalDistance(kruskal), totalDistance(prim))
+}
+
+func TestKNearest(t *testing.T) {
+	points := []vec.Vec2i{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 10, Y: 0}, {X: 12, Y: 0}}
+
+	edges := KNearest(points, 1, Manhattan2i)
+
+	be.Equal(t, len(edges), 2)
+	be.Equal(t, edges[0], Edge{A: 0, B: 1, Distance: 1})
+	be.Equal(t, edges[1], Edge{A: 2, B: 3, Distance: 2})
+}
+
+func TestConnector(t *testing.T) {
+	points := []vec.Vec2i{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 10, Y: 0}, {X: 12, Y: 0}, {X: 0, Y: 1}}
+
+	c := NewConnector(len(points), AllPairs(points, Manhattan2i))
+
+	c.Connect(4)
+	be.Equal(t, c.Components(), 2)
+	be.True(t, c.Connected(0, 4))
+	be.True(t, !c.Connected(0, 2))
+
+	e, ok := c.ConnectUntil(1)
+	be.True(t, ok)
+	be.Equal(t, e, Edge{A: 1, B: 2, Distance: 9})
+
+	_, ok = c.ConnectUntil(1)
+	be.True(t, !ok)
+}
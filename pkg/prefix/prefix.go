@@ -0,0 +1,124 @@
+// Package prefix has precomputed sums and scans over slices and grids, to
+// answer range questions without rescanning the input.
+package prefix
+
+import (
+	"cmp"
+
+	"aoc/pkg/vec"
+)
+
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Sums holds the prefix sums of a slice for range sums in O(1). The sums
+// are kept as int, so narrow value types such as int8 don't overflow.
+type Sums[T Integer] struct {
+	sums []int // sums[i] is the sum of the first i values
+}
+
+func NewSums[T Integer](s []T) Sums[T] {
+	sums := make([]int, len(s)+1)
+	for i, v := range s {
+		sums[i+1] = sums[i] + int(v)
+	}
+	return Sums[T]{sums: sums}
+}
+
+func (p Sums[T]) Len() int {
+	return len(p.sums) - 1
+}
+
+// Sum returns the sum of the values from index from to to, both inclusive.
+// The range is clipped to the slice.
+func (p Sums[T]) Sum(from, to int) int {
+	from = max(from, 0)
+	to = min(to, p.Len()-1)
+	if from > to {
+		return 0
+	}
+	return p.sums[to+1] - p.sums[from]
+}
+
+// SummedArea is a 2D prefix sum table of a grid for rectangle sums in O(1).
+// Like Sums it keeps the sums as int.
+type SummedArea[T Integer] struct {
+	sums [][]int // sums[y][x] is the sum of all cells above and left of (x,y)
+}
+
+// NewSummedArea builds the table of a rectangular grid indexed [y][x].
+func NewSummedArea[T Integer](grid [][]T) *SummedArea[T] {
+	sums := make([][]int, len(grid)+1)
+	width := 0
+	if len(grid) > 0 {
+		width = len(grid[0])
+	}
+	sums[0] = make([]int, width+1)
+	for y, row := range grid {
+		sums[y+1] = make([]int, width+1)
+		rowSum := 0
+		for x, v := range row {
+			rowSum += int(v)
+			sums[y+1][x+1] = sums[y][x+1] + rowSum
+		}
+	}
+	return &SummedArea[T]{sums: sums}
+}
+
+// Bounds returns the box of all cells of the grid.
+func (s *SummedArea[T]) Bounds() vec.AABB {
+	size := vec.Vec2i{X: len(s.sums[0]) - 1, Y: len(s.sums) - 1}
+	return vec.NewAABBFromSize(vec.Vec2i{}, size)
+}
+
+// Sum returns the sum of all cells within box, clipped to the grid.
+func (s *SummedArea[T]) Sum(box vec.AABB) int {
+	box = box.Intersect(s.Bounds())
+	if box.IsEmpty() {
+		return 0
+	}
+	x0, y0 := box.From.X, box.From.Y
+	x1, y1 := box.To.X+1, box.To.Y+1
+	return s.sums[y1][x1] - s.sums[y0][x1] - s.sums[y1][x0] + s.sums[y0][x0]
+}
+
+// Scan walks a rectangular grid in direction d and returns for each cell
+// the accumulation of all cells passed before reaching it. Cells at the
+// edge the walk starts from get init.
+func Scan[T, A any](grid [][]T, d vec.Dir, init A, fn func(acc A, v T) A) [][]A {
+	result := make([][]A, len(grid))
+	for y, row := range grid {
+		result[y] = make([]A, len(row))
+	}
+	if len(grid) == 0 {
+		return result
+	}
+
+	bounds := vec.NewAABBFromSize(vec.Vec2i{}, vec.Vec2i{X: len(grid[0]), Y: len(grid)})
+	step := d.Vec()
+	bounds.ForEach(func(p vec.Vec2i) {
+		if bounds.Contains(p.Sub(step)) {
+			return
+		}
+		acc := init
+		for ; bounds.Contains(p); p = p.Add(step) {
+			result[p.Y][p.X] = acc
+			acc = fn(acc, grid[p.Y][p.X])
+		}
+	})
+	return result
+}
+
+// RunningMax returns for each cell the maximum of all cells passed before
+// it when walking in direction d, see Scan.
+func RunningMax[T cmp.Ordered](grid [][]T, d vec.Dir, init T) [][]T {
+	return Scan(grid, d, init, func(acc, v T) T { return max(acc, v) })
+}
+
+// RunningMin returns for each cell the minimum of all cells passed before
+// it when walking in direction d, see Scan.
+func RunningMin[T cmp.Ordered](grid [][]T, d vec.Dir, init T) [][]T {
+	return Scan(grid, d, init, func(acc, v T) T { return min(acc, v) })
+}
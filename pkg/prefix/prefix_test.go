@@ -0,0 +1,63 @@
+package prefix
+
+import (
+	"fmt"
+	"testing"
+
+	"aoc/pkg/be"
+	"aoc/pkg/vec"
+)
+
+func TestSums(t *testing.T) {
+	p := NewSums([]int{3, 1, 4, 1, 5})
+
+	be.Equal(t, p.Len(), 5)
+	be.Equal(t, p.Sum(0, 4), 14)
+	be.Equal(t, p.Sum(1, 3), 6)
+	be.Equal(t, p.Sum(2, 2), 4)
+	be.Equal(t, p.Sum(-5, 1), 4)
+	be.Equal(t, p.Sum(3, 2), 0)
+}
+
+func TestSummedArea(t *testing.T) {
+	s := NewSummedArea([][]uint8{
+		{1, 2, 3},
+		{4, 5, 6},
+		{7, 8, 9},
+	})
+
+	be.Equal(t, s.Bounds(), vec.AABB{To: vec.Vec2i{X: 2, Y: 2}})
+	be.Equal(t, s.Sum(s.Bounds()), 45)
+	be.Equal(t, s.Sum(vec.AABB{From: vec.Vec2i{X: 1, Y: 1}, To: vec.Vec2i{X: 2, Y: 2}}), 28)
+	be.Equal(t, s.Sum(vec.AABB{From: vec.Vec2i{X: 1, Y: 0}, To: vec.Vec2i{X: 1, Y: 5}}), 15)
+	be.Equal(t, s.Sum(vec.AABB{From: vec.Vec2i{X: 3, Y: 0}, To: vec.Vec2i{X: 4, Y: 1}}), 0)
+}
+
+func TestSummedAreaNarrow(t *testing.T) {
+	grid := make([][]int8, 10)
+	for y := range grid {
+		grid[y] = make([]int8, 10)
+		for x := range grid[y] {
+			grid[y][x] = 9
+		}
+	}
+	s := NewSummedArea(grid)
+	be.Equal(t, s.Sum(s.Bounds()), 900)
+	be.Equal(t, s.Sum(vec.AABB{To: vec.Vec2i{X: 4, Y: 4}}), 225)
+
+	p := NewSums(grid[0])
+	be.Equal(t, p.Sum(0, 9), 90)
+	be.Equal(t, NewSums([]int8{100, 100, -128}).Sum(0, 1), 200)
+}
+
+func TestRunningMax(t *testing.T) {
+	grid := [][]int{
+		{3, 0, 3},
+		{2, 5, 5},
+	}
+
+	be.Equal(t, fmt.Sprint(RunningMax(grid, vec.E, -1)), "[[-1 3 3] [-1 2 5]]")
+	be.Equal(t, fmt.Sprint(RunningMax(grid, vec.W, -1)), "[[3 3 -1] [5 5 -1]]")
+	be.Equal(t, fmt.Sprint(RunningMax(grid, vec.S, -1)), "[[-1 -1 -1] [3 0 3]]")
+	be.Equal(t, fmt.Sprint(RunningMin(grid, vec.N, 9)), "[[2 5 5] [9 9 9]]")
+}
@@ -0,0 +1,96 @@
+// Package rangetree has trees answering range queries over a sequence of
+// values that keeps changing, each in O(log n).
+package rangetree
+
+import (
+	"math/bits"
+)
+
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Fenwick is a binary indexed tree for prefix sums with point updates.
+type Fenwick[T Number] struct {
+	tree []T // 1-based, tree[i] holds the sum of the last i&-i values up to i
+}
+
+func NewFenwick[T Number](n int) *Fenwick[T] {
+	return &Fenwick[T]{tree: make([]T, n+1)}
+}
+
+// NewFenwickFrom builds the tree of values in O(n).
+func NewFenwickFrom[T Number](values []T) *Fenwick[T] {
+	tree := make([]T, len(values)+1)
+	copy(tree[1:], values)
+	for i := 1; i < len(tree); i++ {
+		if parent := i + i&-i; parent < len(tree) {
+			tree[parent] += tree[i]
+		}
+	}
+	return &Fenwick[T]{tree: tree}
+}
+
+func (f *Fenwick[T]) Len() int {
+	return len(f.tree) - 1
+}
+
+// Add adds delta to the value at index i.
+func (f *Fenwick[T]) Add(i int, delta T) {
+	for i++; i < len(f.tree); i += i & -i {
+		f.tree[i] += delta
+	}
+}
+
+// Set replaces the value at index i.
+func (f *Fenwick[T]) Set(i int, v T) {
+	f.Add(i, v-f.Get(i))
+}
+
+func (f *Fenwick[T]) Get(i int) T {
+	return f.Sum(i, i)
+}
+
+// Prefix returns the sum of the first n values.
+func (f *Fenwick[T]) Prefix(n int) T {
+	var sum T
+	for i := min(n, f.Len()); i > 0; i -= i & -i {
+		sum += f.tree[i]
+	}
+	return sum
+}
+
+// Sum returns the sum of the values from index from to to, both inclusive.
+func (f *Fenwick[T]) Sum(from, to int) T {
+	if from > to {
+		return 0
+	}
+	return f.Prefix(to+1) - f.Prefix(max(from, 0))
+}
+
+// LowerBound returns the smallest index i for which the sum of the values
+// up to and including i is at least target, false if there is none. It
+// requires all values to be non-negative.
+func (f *Fenwick[T]) LowerBound(target T) (int, bool) {
+	if f.Len() == 0 {
+		return 0, false
+	}
+	if target <= 0 {
+		return 0, true
+	}
+
+	pos := 0
+	var sum T
+	for step := 1 << (bits.Len(uint(f.Len())) - 1); step > 0; step >>= 1 {
+		if next := pos + step; next < len(f.tree) && sum+f.tree[next] < target {
+			pos = next
+			sum += f.tree[next]
+		}
+	}
+	if pos == f.Len() {
+		return 0, false
+	}
+	return pos, true
+}
@@ -0,0 +1,83 @@
+package rangetree
+
+import (
+	"math"
+	"testing"
+
+	"aoc/pkg/be"
+)
+
+func TestFenwick(t *testing.T) {
+	f := NewFenwickFrom([]int{3, 1, 4, 1, 5, 9, 2})
+
+	be.Equal(t, f.Len(), 7)
+	be.Equal(t, f.Prefix(0), 0)
+	be.Equal(t, f.Prefix(3), 8)
+	be.Equal(t, f.Prefix(7), 25)
+	be.Equal(t, f.Sum(2, 4), 10)
+	be.Equal(t, f.Get(5), 9)
+
+	f.Add(1, 10)
+	f.Set(6, 0)
+	be.Equal(t, f.Sum(0, 6), 33)
+	be.Equal(t, f.Get(1), 11)
+
+	i, ok := f.LowerBound(14)
+	be.True(t, ok)
+	be.Equal(t, i, 1)
+	i, ok = f.LowerBound(15)
+	be.True(t, ok)
+	be.Equal(t, i, 2)
+	_, ok = f.LowerBound(34)
+	be.True(t, !ok)
+}
+
+func TestFenwick_Add(t *testing.T) {
+	values := []int{2, 7, 1, 8, 2, 8}
+	f := NewFenwick[int](len(values))
+	for i, v := range values {
+		f.Add(i, v)
+	}
+
+	be.Equal(t, f.Prefix(len(values)), 28)
+	be.Equal(t, f.Sum(3, 3), 8)
+}
+
+func TestSegmentTree_Query(t *testing.T) {
+	s := NewSegmentTree([]int{5, 2, 8, 1, 9, 3}, Min(math.MaxInt))
+
+	be.Equal(t, s.Query(0, 5), 1)
+	be.Equal(t, s.Query(0, 2), 2)
+	be.Equal(t, s.Query(4, 10), 3)
+	be.Equal(t, s.Query(3, 2), math.MaxInt)
+
+	s.Set(3, 7)
+	be.Equal(t, s.Query(0, 5), 2)
+	be.Equal(t, s.Get(3), 7)
+
+	// non commutative monoid keeps the order
+	concat := Monoid[string]{Combine: func(a, b string) string { return a + b }}
+	c := NewSegmentTree([]string{"a", "b", "c", "d", "e"}, concat)
+	be.Equal(t, c.Query(1, 3), "bcd")
+}
+
+func TestSegmentTree_FindFirst(t *testing.T) {
+	s := NewSegmentTree([]int{1, 3, 0, 4, 2}, Max(0))
+
+	i, ok := s.FindFirst(func(v int) bool { return v >= 4 })
+	be.True(t, ok)
+	be.Equal(t, i, 3)
+
+	s.Set(1, 5)
+	i, ok = s.FindFirst(func(v int) bool { return v >= 4 })
+	be.True(t, ok)
+	be.Equal(t, i, 1)
+
+	_, ok = s.FindFirst(func(v int) bool { return v >= 6 })
+	be.True(t, !ok)
+
+	sums := NewSegmentTree([]int{3, 1, 4}, Sum[int]())
+	i, ok = sums.FindFirst(func(v int) bool { return v >= 5 })
+	be.True(t, ok)
+	be.Equal(t, i, 2)
+}
@@ -0,0 +1,110 @@
+package rangetree
+
+import (
+	"cmp"
+)
+
+// Monoid is an associative operation with its identity element, e.g. max
+// with the smallest possible value.
+type Monoid[T any] struct {
+	Identity T
+	Combine  func(a, b T) T
+}
+
+func Sum[T Number]() Monoid[T] {
+	return Monoid[T]{Combine: func(a, b T) T { return a + b }}
+}
+
+// Max combines to the maximum, lowest must not be larger than any value.
+func Max[T cmp.Ordered](lowest T) Monoid[T] {
+	return Monoid[T]{Identity: lowest, Combine: func(a, b T) T { return max(a, b) }}
+}
+
+// Min combines to the minimum, highest must not be smaller than any value.
+func Min[T cmp.Ordered](highest T) Monoid[T] {
+	return Monoid[T]{Identity: highest, Combine: func(a, b T) T { return min(a, b) }}
+}
+
+// SegmentTree combines any range of values with a monoid.
+type SegmentTree[T any] struct {
+	m    Monoid[T]
+	n    int
+	size int // number of leaves, a power of two
+	tree []T // 1-based heap layout, leaves start at size
+}
+
+func NewSegmentTree[T any](values []T, m Monoid[T]) *SegmentTree[T] {
+	size := 1
+	for size < len(values) {
+		size *= 2
+	}
+
+	tree := make([]T, 2*size)
+	for i := range tree {
+		tree[i] = m.Identity
+	}
+	copy(tree[size:], values)
+	for i := size - 1; i > 0; i-- {
+		tree[i] = m.Combine(tree[2*i], tree[2*i+1])
+	}
+	return &SegmentTree[T]{m: m, n: len(values), size: size, tree: tree}
+}
+
+func (s *SegmentTree[T]) Len() int {
+	return s.n
+}
+
+func (s *SegmentTree[T]) Get(i int) T {
+	return s.tree[s.size+i]
+}
+
+func (s *SegmentTree[T]) Set(i int, v T) {
+	i += s.size
+	s.tree[i] = v
+	for i /= 2; i > 0; i /= 2 {
+		s.tree[i] = s.m.Combine(s.tree[2*i], s.tree[2*i+1])
+	}
+}
+
+// Query combines the values from index from to to, both inclusive, in
+// order. The range is clipped to the tree.
+func (s *SegmentTree[T]) Query(from, to int) T {
+	left, right := s.m.Identity, s.m.Identity
+	lo, hi := max(from, 0)+s.size, min(to, s.n-1)+s.size+1
+	for lo < hi {
+		if lo%2 == 1 {
+			left = s.m.Combine(left, s.tree[lo])
+			lo++
+		}
+		if hi%2 == 1 {
+			hi--
+			right = s.m.Combine(s.tree[hi], right)
+		}
+		lo /= 2
+		hi /= 2
+	}
+	return s.m.Combine(left, right)
+}
+
+// FindFirst returns the smallest index i for which pred holds on the
+// combination of all values up to and including i, false if there is none.
+// pred has to be monotone, once true it stays true for larger ranges. With
+// Max and pred v >= k it finds the leftmost value of at least k.
+func (s *SegmentTree[T]) FindFirst(pred func(T) bool) (int, bool) {
+	if s.n == 0 || !pred(s.tree[1]) {
+		return 0, false
+	}
+
+	acc := s.m.Identity
+	node := 1
+	for node < s.size {
+		left := 2 * node
+		if combined := s.m.Combine(acc, s.tree[left]); pred(combined) {
+			node = left
+		} else {
+			acc = combined
+			node = left + 1
+		}
+	}
+	return node - s.size, true
+}
@@ -0,0 +1,103 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"strconv"
+
+	"aoc/pkg/vec"
+)
+
+// Ramp is a colour gradient through evenly spaced stops.
+type Ramp []color.RGBA
+
+var (
+	// Heat goes from black through red and yellow to white.
+	Heat = Ramp{{0, 0, 0, 255}, {200, 0, 0, 255}, {255, 200, 0, 255}, {255, 255, 255, 255}}
+
+	// Viridis goes from purple through blue and green to yellow and stays
+	// readable in gray scale.
+	Viridis = Ramp{{68, 1, 84, 255}, {59, 82, 139, 255}, {33, 145, 140, 255}, {94, 201, 98, 255}, {253, 231, 37, 255}}
+
+	// Grays goes from black to white.
+	Grays = Ramp{{0, 0, 0, 255}, {255, 255, 255, 255}}
+)
+
+// At returns the colour at t, from 0 for the first to 1 for the last stop.
+func (r Ramp) At(t float64) color.RGBA {
+	if len(r) == 0 {
+		return Magenta
+	}
+	t = min(1, max(0, t)) * float64(len(r)-1)
+	i := min(int(t), len(r)-2)
+	if i < 0 {
+		return r[0]
+	}
+
+	f := t - float64(i)
+	lerp := func(a, b uint8) uint8 {
+		return uint8(math.Round((1-f)*float64(a) + f*float64(b)))
+	}
+	a, b := r[i], r[i+1]
+	return color.RGBA{lerp(a.R, b.R), lerp(a.G, b.G), lerp(a.B, b.B), lerp(a.A, b.A)}
+}
+
+// Heatmap colours the cells in values along ramp, from the lowest value
+// at its start to the highest at its end, and adds a legend with the range
+// below the grid.
+func (m *Image) Heatmap(values map[vec.Vec2i]int, ramp Ramp) {
+	if len(values) == 0 {
+		return
+	}
+
+	lo, hi := math.MaxInt, math.MinInt
+	for _, v := range values {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+	for p, v := range values {
+		m.Cell(p, ramp.At(normalize(v, lo, hi)))
+	}
+	m.legend(lo, hi, ramp)
+}
+
+func normalize(v, lo, hi int) float64 {
+	if hi == lo {
+		return 0
+	}
+	return float64(v-lo) / float64(hi-lo)
+}
+
+const (
+	legendPadding = 4
+	legendBar     = 64
+)
+
+// legend grows the image by a strip with a gradient of ramp between the
+// labels lo and hi.
+func (m *Image) legend(lo, hi int, ramp Ramp) {
+	scale := max(1, m.CellSize/8)
+	loText, hiText := strconv.Itoa(lo), strconv.Itoa(hi)
+	loWidth, hiWidth := TextWidth(loText, scale), TextWidth(hiText, scale)
+	height := GlyphHeight*scale + 2*legendPadding
+
+	old := m.img.Bounds()
+	width := max(old.Dx(), loWidth+hiWidth+legendBar+4*legendPadding)
+	grown := image.NewRGBA(image.Rect(0, 0, width, old.Dy()+height))
+	draw.Draw(grown, grown.Bounds(), &image.Uniform{C: White}, image.Point{}, draw.Src)
+	draw.Draw(grown, old, m.img, image.Point{}, draw.Src)
+	m.img = grown
+
+	top := old.Dy() + legendPadding
+	middle := top + GlyphHeight*scale/2
+	Text(m.img, vec.Vec2i{X: legendPadding + loWidth/2, Y: middle}, loText, scale, Black)
+	Text(m.img, vec.Vec2i{X: width - legendPadding - hiWidth + hiWidth/2, Y: middle}, hiText, scale, Black)
+
+	from := loWidth + 2*legendPadding
+	to := width - hiWidth - 2*legendPadding
+	for x := from; x < to; x++ {
+		c := ramp.At(float64(x-from) / float64(max(1, to-from-1)))
+		draw.Draw(m.img, image.Rect(x, top, x+1, top+GlyphHeight*scale), &image.Uniform{C: c}, image.Point{}, draw.Src)
+	}
+}
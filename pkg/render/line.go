@@ -1,4 +1,4 @@
-package iso3d
+package render
 
 import (
 	"image"
@@ -7,7 +7,8 @@ import (
 	"aoc/pkg/vec"
 )
 
-func drawLine(rgba *image.RGBA, start, end vec.Vec2i, c color.Color) {
+// Line draws a one pixel wide line from start to end, both inclusive.
+func Line(rgba *image.RGBA, start, end vec.Vec2i, c color.Color) {
 	// https://en.wikipedia.org/wiki/Bresenham%27s_line_algorithm#Algorithm_for_integer_arithmetic
 	if abs(end.Y-start.Y) < abs(end.X-start.X) {
 		if start.X > end.X {
@@ -0,0 +1,223 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"aoc/pkg/vec"
+)
+
+// Recorder collects the states of a grid simulation as text frames, one
+// call per step, and writes them as animated GIF or as asciinema cast.
+//
+// Long simulations are thinned out: only every Every-th step is kept, and
+// whenever there are more than MaxFrames frames, every other frame is
+// dropped and Every doubles, so the frames stay evenly spread over the
+// whole run. A nil Recorder ignores all calls, so simulations can take one
+// unconditionally.
+type Recorder struct {
+	// Palette colours the runes of the frames in the GIF.
+	Palette Palette
+
+	// CellSize is the size of a rune in the GIF in pixels.
+	CellSize int
+
+	// Delay is the time each frame is shown in 100ths of a second.
+	Delay int
+
+	// Every keeps only every n-th step, defaults to 1.
+	Every int
+
+	// MaxFrames is the most frames kept, 0 keeps all.
+	MaxFrames int
+
+	steps  int
+	frames []recordedFrame
+}
+
+type recordedFrame struct {
+	step int
+	text string
+}
+
+func (f recordedFrame) lines() []string {
+	return strings.Split(strings.TrimSuffix(f.text, "\n"), "\n")
+}
+
+// NewRecorder returns a recorder that keeps at most 500 frames and shows
+// them at 20 frames per second.
+func NewRecorder(palette Palette, cellSize int) *Recorder {
+	return &Recorder{
+		Palette:   palette,
+		CellSize:  cellSize,
+		Delay:     5,
+		MaxFrames: 500,
+	}
+}
+
+// Record counts a step of the simulation and keeps the frame rendered by
+// frame, which is only called for steps that are kept. Lines of a frame
+// are separated by newlines.
+func (r *Recorder) Record(frame func() string) {
+	if r == nil {
+		return
+	}
+	step := r.steps
+	r.steps++
+	if step%r.every() != 0 {
+		return
+	}
+
+	r.frames = append(r.frames, recordedFrame{step: step, text: frame()})
+	if r.MaxFrames > 0 && len(r.frames) > r.MaxFrames {
+		r.Every = 2 * r.every()
+		r.frames = slices.DeleteFunc(r.frames, func(f recordedFrame) bool {
+			return f.step%r.Every != 0
+		})
+	}
+}
+
+// Final keeps frame as the end state, unless the last step was kept
+// anyway. It may exceed MaxFrames by one.
+func (r *Recorder) Final(frame func() string) {
+	if r == nil {
+		return
+	}
+	if n := len(r.frames); n > 0 && r.frames[n-1].step == r.steps-1 {
+		return
+	}
+	r.frames = append(r.frames, recordedFrame{step: r.steps, text: frame()})
+	r.steps++
+}
+
+func (r *Recorder) every() int {
+	return max(1, r.Every)
+}
+
+// Steps returns the number of recorded steps, kept or not.
+func (r *Recorder) Steps() int {
+	if r == nil {
+		return 0
+	}
+	return r.steps
+}
+
+// Len returns the number of kept frames.
+func (r *Recorder) Len() int {
+	if r == nil {
+		return 0
+	}
+	return len(r.frames)
+}
+
+// size returns the largest frame in runes.
+func (r *Recorder) size() (width, height int) {
+	for _, f := range r.frames {
+		lines := f.lines()
+		height = max(height, len(lines))
+		for _, line := range lines {
+			width = max(width, utf8.RuneCountInString(line))
+		}
+	}
+	return width, height
+}
+
+// EncodeGIF writes the frames as animated GIF with a cell per rune.
+func (r *Recorder) EncodeGIF(w io.Writer) error {
+	if r.Len() == 0 {
+		return fmt.Errorf("recorder has no frames")
+	}
+
+	pal := r.gifPalette()
+	width, height := r.size()
+	anim := &gif.GIF{}
+	for _, f := range r.frames {
+		m := New(vec.Vec2i{X: width, Y: height}, r.CellSize)
+		m.fillLines(f.lines(), r.Palette)
+
+		paletted := image.NewPaletted(m.img.Bounds(), pal)
+		draw.Draw(paletted, paletted.Bounds(), m.img, image.Point{}, draw.Src)
+		anim.Image = append(anim.Image, paletted)
+		anim.Delay = append(anim.Delay, r.Delay)
+	}
+	return gif.EncodeAll(w, anim)
+}
+
+// gifPalette holds the colours of the palette, the background and the
+// colour of unknown runes, in a fixed order.
+func (r *Recorder) gifPalette() color.Palette {
+	pal := color.Palette{White, Magenta}
+	runes := make([]rune, 0, len(r.Palette))
+	for k := range r.Palette {
+		runes = append(runes, k)
+	}
+	slices.Sort(runes)
+	for _, k := range runes {
+		if len(pal) == 256 {
+			break
+		}
+		if !slices.ContainsFunc(pal, func(c color.Color) bool { return sameColor(c, r.Palette[k]) }) {
+			pal = append(pal, r.Palette[k])
+		}
+	}
+	return pal
+}
+
+func sameColor(a, b color.Color) bool {
+	return color.RGBAModel.Convert(a) == color.RGBAModel.Convert(b)
+}
+
+// EncodeCast writes the frames as asciinema cast, version 2, which clears
+// the terminal and prints the next frame every Delay.
+func (r *Recorder) EncodeCast(w io.Writer) error {
+	if r.Len() == 0 {
+		return fmt.Errorf("recorder has no frames")
+	}
+
+	width, height := r.size()
+	enc := json.NewEncoder(w)
+	header := map[string]any{"version": 2, "width": width, "height": height}
+	if err := enc.Encode(header); err != nil {
+		return err
+	}
+	for i, f := range r.frames {
+		at := float64(i*r.Delay) / 100
+		out := "\033[H\033[2J" + strings.Join(f.lines(), "\r\n")
+		if err := enc.Encode([]any{at, "o", out}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteFile writes the frames to path as GIF or, if it ends in .cast, as
+// asciinema cast. A nil Recorder writes nothing.
+func (r *Recorder) WriteFile(path string) error {
+	if r == nil {
+		return nil
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if filepath.Ext(path) == ".cast" {
+		err = r.EncodeCast(f)
+	} else {
+		err = r.EncodeGIF(f)
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
@@ -0,0 +1,158 @@
+package render
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"image/gif"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"aoc/pkg/be"
+)
+
+// recordSteps records n steps, frame i shows i as text.
+func recordSteps(r *Recorder, n int) {
+	for i := 0; i < n; i++ {
+		r.Record(func() string { return strconv.Itoa(i) })
+	}
+}
+
+func keptSteps(r *Recorder) []int {
+	var steps []int
+	for _, f := range r.frames {
+		steps = append(steps, f.step)
+	}
+	return steps
+}
+
+func TestRecorder_Record(t *testing.T) {
+	r := NewRecorder(testPalette, 1)
+	r.Every = 3
+	recordSteps(r, 10)
+	be.Equal(t, r.Steps(), 10)
+	be.True(t, slices.Equal(keptSteps(r), []int{0, 3, 6, 9}))
+	be.Equal(t, r.frames[2].text, "6")
+}
+
+func TestRecorder_RecordMaxFrames(t *testing.T) {
+	r := NewRecorder(testPalette, 1)
+	r.MaxFrames = 4
+	recordSteps(r, 10)
+	be.Equal(t, r.Every, 4)
+	be.True(t, slices.Equal(keptSteps(r), []int{0, 4, 8}))
+
+	// frames are only rendered for kept steps
+	calls := 0
+	for i := 0; i < 7; i++ {
+		r.Record(func() string {
+			calls++
+			return ""
+		})
+	}
+	be.Equal(t, calls, 2)
+	be.Equal(t, r.Every, 8)
+	be.True(t, slices.Equal(keptSteps(r), []int{0, 8, 16}))
+}
+
+func TestRecorder_Final(t *testing.T) {
+	r := NewRecorder(testPalette, 1)
+	r.Every = 4
+	recordSteps(r, 6)
+	r.Final(func() string { return "end" })
+	be.True(t, slices.Equal(keptSteps(r), []int{0, 4, 6}))
+	be.Equal(t, r.frames[2].text, "end")
+
+	// the last step is already kept
+	r = NewRecorder(testPalette, 1)
+	recordSteps(r, 3)
+	r.Final(func() string { return "end" })
+	be.Equal(t, r.Len(), 3)
+}
+
+func TestRecorder_Nil(t *testing.T) {
+	var r *Recorder
+	r.Record(func() string { panic("not recording") })
+	r.Final(func() string { panic("not recording") })
+	be.Equal(t, r.Len(), 0)
+	be.NoError(t, r.WriteFile(filepath.Join(t.TempDir(), "nil.gif")))
+}
+
+func TestRecorder_EncodeGIF(t *testing.T) {
+	r := NewRecorder(testPalette, 3)
+	r.Record(func() string { return "#.\n.#\n" })
+	r.Record(func() string { return "##\n##\n..\n" })
+
+	var buf bytes.Buffer
+	be.NoError(t, r.EncodeGIF(&buf))
+	anim, err := gif.DecodeAll(&buf)
+	be.NoError(t, err)
+	be.Equal(t, len(anim.Image), 2)
+	be.True(t, slices.Equal(anim.Delay, []int{5, 5}))
+
+	// all frames have the size of the largest
+	for _, frame := range anim.Image {
+		be.Equal(t, frame.Bounds().Dx(), 6)
+		be.Equal(t, frame.Bounds().Dy(), 9)
+	}
+	be.True(t, sameColor(anim.Image[0].At(0, 0), Black))
+	be.True(t, sameColor(anim.Image[0].At(3, 0), White))
+	be.True(t, sameColor(anim.Image[1].At(3, 3), Black))
+
+	be.AnError(t, NewRecorder(testPalette, 1).EncodeGIF(&buf))
+}
+
+func TestRecorder_EncodeCast(t *testing.T) {
+	r := NewRecorder(testPalette, 1)
+	r.Delay = 50
+	r.Record(func() string { return "#..\n.#.\n" })
+	r.Record(func() string { return "###\n" })
+
+	var buf bytes.Buffer
+	be.NoError(t, r.EncodeCast(&buf))
+
+	scanner := bufio.NewScanner(&buf)
+	var lines []string
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	be.Equal(t, len(lines), 3)
+
+	var header map[string]int
+	be.NoError(t, json.Unmarshal([]byte(lines[0]), &header))
+	be.Equal(t, header["version"], 2)
+	be.Equal(t, header["width"], 3)
+	be.Equal(t, header["height"], 2)
+
+	var event []any
+	be.NoError(t, json.Unmarshal([]byte(lines[2]), &event))
+	be.Equal(t, event[0], any(0.5))
+	be.Equal(t, event[1], any("o"))
+	be.True(t, strings.HasSuffix(event[2].(string), "###"))
+
+	be.NoError(t, json.Unmarshal([]byte(lines[1]), &event))
+	be.True(t, strings.HasSuffix(event[2].(string), "#..\r\n.#."))
+}
+
+func TestRecorder_WriteFile(t *testing.T) {
+	r := NewRecorder(testPalette, 1)
+	recordSteps(r, 3)
+
+	dir := t.TempDir()
+	be.NoError(t, r.WriteFile(filepath.Join(dir, "steps.cast")))
+	be.NoError(t, r.WriteFile(filepath.Join(dir, "steps.gif")))
+
+	cast, err := os.ReadFile(filepath.Join(dir, "steps.cast"))
+	be.NoError(t, err)
+	be.True(t, bytes.HasPrefix(cast, []byte(`{"height":1,"version":2,"width":1}`)))
+
+	f, err := os.Open(filepath.Join(dir, "steps.gif"))
+	be.NoError(t, err)
+	defer f.Close()
+	_, err = gif.DecodeAll(f)
+	be.NoError(t, err)
+}
@@ -0,0 +1,173 @@
+// Package render draws 2D grids as images, with a square of pixels per cell
+// and overlays for paths, point sets and heatmaps on top.
+package render
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"unicode/utf8"
+
+	"aoc/pkg/sets"
+	"aoc/pkg/vec"
+)
+
+var (
+	White   = color.RGBA{255, 255, 255, 255}
+	Black   = color.RGBA{0, 0, 0, 255}
+	Gray    = color.RGBA{128, 128, 128, 255}
+	Red     = color.RGBA{255, 0, 0, 255}
+	Green   = color.RGBA{0, 200, 0, 255}
+	Blue    = color.RGBA{0, 0, 255, 255}
+	Yellow  = color.RGBA{255, 220, 0, 255}
+	Magenta = color.RGBA{255, 0, 255, 255}
+)
+
+// Palette maps the runes of a character grid to colours. Runes without a
+// colour are drawn in Magenta, so they stand out.
+type Palette map[rune]color.Color
+
+// Color returns the colour of r.
+func (p Palette) Color(r rune) color.Color {
+	if c, ok := p[r]; ok {
+		return c
+	}
+	return Magenta
+}
+
+// Image is a grid of Size cells drawn as squares of CellSize pixels. Cells
+// outside the grid are ignored by all drawing methods.
+type Image struct {
+	Size     vec.Vec2i
+	CellSize int
+
+	img *image.RGBA
+}
+
+// New returns a white image of a grid of size cells.
+func New(size vec.Vec2i, cellSize int) *Image {
+	m := &Image{Size: size, CellSize: max(1, cellSize)}
+	m.img = image.NewRGBA(image.Rect(0, 0, size.X*m.CellSize, size.Y*m.CellSize))
+	draw.Draw(m.img, m.img.Bounds(), &image.Uniform{C: White}, image.Point{}, draw.Src)
+	return m
+}
+
+// Grid draws a grid of rows, colouring each cell by its value.
+func Grid[T any](grid [][]T, cellSize int, colorOf func(v T) color.Color) *Image {
+	size := vec.Vec2i{Y: len(grid)}
+	for _, row := range grid {
+		size.X = max(size.X, len(row))
+	}
+
+	m := New(size, cellSize)
+	for y, row := range grid {
+		for x, v := range row {
+			m.Cell(vec.Vec2i{X: x, Y: y}, colorOf(v))
+		}
+	}
+	return m
+}
+
+// Bytes draws a character grid, such as a puzzle input, with palette.
+func Bytes(grid [][]byte, cellSize int, palette Palette) *Image {
+	return Grid(grid, cellSize, func(b byte) color.Color {
+		return palette.Color(rune(b))
+	})
+}
+
+// Lines draws the lines of a character grid with palette.
+func Lines(lines []string, cellSize int, palette Palette) *Image {
+	size := vec.Vec2i{Y: len(lines)}
+	for _, line := range lines {
+		size.X = max(size.X, utf8.RuneCountInString(line))
+	}
+
+	m := New(size, cellSize)
+	m.fillLines(lines, palette)
+	return m
+}
+
+func (m *Image) fillLines(lines []string, palette Palette) {
+	for y, line := range lines {
+		x := 0
+		for _, r := range line {
+			m.Cell(vec.Vec2i{X: x, Y: y}, palette.Color(r))
+			x++
+		}
+	}
+}
+
+// JoinLines returns a character grid as text, e.g. to record it.
+func JoinLines(grid [][]byte) string {
+	return string(bytes.Join(grid, []byte{'\n'}))
+}
+
+// Fill colours every cell of the grid by its position.
+func (m *Image) Fill(colorOf func(p vec.Vec2i) color.Color) {
+	for y := 0; y < m.Size.Y; y++ {
+		for x := 0; x < m.Size.X; x++ {
+			p := vec.Vec2i{X: x, Y: y}
+			m.Cell(p, colorOf(p))
+		}
+	}
+}
+
+// Cell fills the cell at p with c.
+func (m *Image) Cell(p vec.Vec2i, c color.Color) {
+	m.inset(p, 0, c)
+}
+
+// Points marks each point with a square a bit smaller than its cell, so
+// the cell colour remains visible at the border.
+func (m *Image) Points(points sets.Set[vec.Vec2i], c color.Color) {
+	for p := range points {
+		m.inset(p, m.CellSize/4, c)
+	}
+}
+
+// Path draws lines through the centers of consecutive cells of path, about
+// a third of a cell wide. Points of a path need not be adjacent.
+func (m *Image) Path(path []vec.Vec2i, c color.Color) {
+	if len(path) == 1 {
+		m.inset(path[0], m.CellSize/3, c)
+	}
+
+	width := max(1, m.CellSize/3)
+	for i := 1; i < len(path); i++ {
+		start, end := m.center(path[i-1]), m.center(path[i])
+		for dy := 0; dy < width; dy++ {
+			for dx := 0; dx < width; dx++ {
+				d := vec.Vec2i{X: dx - (width-1)/2, Y: dy - (width-1)/2}
+				Line(m.img, start.Add(d), end.Add(d), c)
+			}
+		}
+	}
+}
+
+// inset fills the cell at p with c, leaving a border of border pixels.
+func (m *Image) inset(p vec.Vec2i, border int, c color.Color) {
+	if p.X < 0 || p.Y < 0 || p.X >= m.Size.X || p.Y >= m.Size.Y {
+		return
+	}
+	r := image.Rect(p.X*m.CellSize, p.Y*m.CellSize, (p.X+1)*m.CellSize, (p.Y+1)*m.CellSize)
+	r = r.Inset(border)
+	draw.Draw(m.img, r, &image.Uniform{C: c}, image.Point{}, draw.Over)
+}
+
+// center returns the pixel in the middle of the cell at p.
+func (m *Image) center(p vec.Vec2i) vec.Vec2i {
+	return vec.Vec2i{X: p.X*m.CellSize + m.CellSize/2, Y: p.Y*m.CellSize + m.CellSize/2}
+}
+
+// AsImage returns the rendered image, including any legends below the grid.
+func (m *Image) AsImage() image.Image {
+	return m.img
+}
+
+// EncodePNG writes the image as PNG.
+func (m *Image) EncodePNG(w io.Writer) error {
+	return png.Encode(w, m.img)
+}
@@ -0,0 +1,126 @@
+package render
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"aoc/pkg/be"
+	"aoc/pkg/sets"
+	"aoc/pkg/vec"
+)
+
+var testPalette = Palette{
+	'.': White,
+	'#': Black,
+}
+
+func TestBytes(t *testing.T) {
+	grid := [][]byte{
+		[]byte("#.."),
+		[]byte(".#?"),
+	}
+	m := Bytes(grid, 4, testPalette)
+	be.Equal(t, m.AsImage().Bounds().Dx(), 12)
+	be.Equal(t, m.AsImage().Bounds().Dy(), 8)
+
+	be.Equal(t, m.img.RGBAAt(0, 0), Black)
+	be.Equal(t, m.img.RGBAAt(3, 3), Black)
+	be.Equal(t, m.img.RGBAAt(4, 0), White)
+	be.Equal(t, m.img.RGBAAt(5, 5), Black)
+	be.Equal(t, m.img.RGBAAt(9, 5), Magenta)
+}
+
+func TestLines(t *testing.T) {
+	m := Lines([]string{"#.", "#"}, 1, testPalette)
+	be.Equal(t, m.Size, vec.Vec2i{X: 2, Y: 2})
+	be.Equal(t, m.img.RGBAAt(0, 1), Black)
+	be.Equal(t, m.img.RGBAAt(1, 1), White)
+}
+
+func TestImage_Fill(t *testing.T) {
+	m := New(vec.Vec2i{X: 3, Y: 3}, 2)
+	m.Fill(func(p vec.Vec2i) color.Color {
+		if p.X == p.Y {
+			return Red
+		}
+		return Blue
+	})
+	be.Equal(t, m.img.RGBAAt(5, 5), Red)
+	be.Equal(t, m.img.RGBAAt(5, 0), Blue)
+}
+
+func TestImage_Points(t *testing.T) {
+	m := New(vec.Vec2i{X: 3, Y: 3}, 8)
+	points := sets.New[vec.Vec2i]()
+	points.PutAll([]vec.Vec2i{{X: 1, Y: 1}, {X: 5, Y: 5}})
+	m.Points(points, Red)
+
+	// inset by a quarter of the cell
+	be.Equal(t, m.img.RGBAAt(8, 8), White)
+	be.Equal(t, m.img.RGBAAt(10, 10), Red)
+	be.Equal(t, m.img.RGBAAt(13, 13), Red)
+	be.Equal(t, m.img.RGBAAt(14, 14), White)
+}
+
+func TestImage_Path(t *testing.T) {
+	m := New(vec.Vec2i{X: 5, Y: 5}, 3)
+	m.Path([]vec.Vec2i{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4}}, Green)
+
+	for x := 1; x <= 13; x++ {
+		be.Equal(t, m.img.RGBAAt(x, 1), Green)
+	}
+	for y := 1; y <= 13; y++ {
+		be.Equal(t, m.img.RGBAAt(13, y), Green)
+	}
+	be.Equal(t, m.img.RGBAAt(1, 4), White)
+}
+
+func TestRamp_At(t *testing.T) {
+	be.Equal(t, Grays.At(0), Black)
+	be.Equal(t, Grays.At(1), White)
+	be.Equal(t, Grays.At(0.5), color.RGBA{128, 128, 128, 255})
+	be.Equal(t, Grays.At(-1), Black)
+	be.Equal(t, Grays.At(2), White)
+	be.Equal(t, Heat.At(1.0/3), Heat[1])
+	be.Equal(t, Ramp{Red}.At(0.7), Red)
+}
+
+func TestImage_Heatmap(t *testing.T) {
+	m := New(vec.Vec2i{X: 3, Y: 1}, 4)
+	m.Heatmap(map[vec.Vec2i]int{
+		{X: 0}: 10,
+		{X: 1}: 15,
+		{X: 2}: 20,
+	}, Grays)
+
+	be.Equal(t, m.img.RGBAAt(1, 1), Black)
+	be.Equal(t, m.img.RGBAAt(5, 1), color.RGBA{128, 128, 128, 255})
+	be.Equal(t, m.img.RGBAAt(9, 1), White)
+
+	// the legend widens and extends the image
+	bounds := m.AsImage().Bounds()
+	be.True(t, bounds.Dx() > 12)
+	be.Equal(t, bounds.Dy(), 4+GlyphHeight+2*legendPadding)
+
+	// cells are still inside the grid only
+	m.Cell(vec.Vec2i{X: 5}, Red)
+	be.Equal(t, m.img.RGBAAt(21, 1), White)
+}
+
+func TestImage_EncodePNG(t *testing.T) {
+	m := Lines([]string{"#.#"}, 2, testPalette)
+
+	var buf bytes.Buffer
+	be.NoError(t, m.EncodePNG(&buf))
+	img, err := png.Decode(&buf)
+	be.NoError(t, err)
+	be.Equal(t, img.Bounds(), m.AsImage().Bounds())
+}
+
+func TestTextWidth(t *testing.T) {
+	be.Equal(t, TextWidth("", 1), 0)
+	be.Equal(t, TextWidth("1", 1), GlyphWidth)
+	be.Equal(t, TextWidth("42", 2), (2*GlyphWidth+1)*2)
+}
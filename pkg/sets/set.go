@@ -1,7 +1,10 @@
 package sets
 
 import (
+	"cmp"
 	"fmt"
+	"reflect"
+	"slices"
 	"strings"
 )
 
@@ -26,6 +29,10 @@ func (s Set[K]) Has(e K) bool {
 	return ok
 }
 
+func (s Set[K]) Remove(e K) {
+	delete(s, e)
+}
+
 func (s Set[K]) Subtract(b Set[K]) {
 	for k := range b {
 		if s.Has(k) {
@@ -34,6 +41,64 @@ func (s Set[K]) Subtract(b Set[K]) {
 	}
 }
 
+func (s Set[K]) Clone() Set[K] {
+	n := make(Set[K], len(s))
+	for k := range s {
+		n.Put(k)
+	}
+	return n
+}
+
+// Equal reports whether both sets contain exactly the same elements.
+func (s Set[K]) Equal(b Set[K]) bool {
+	if len(s) != len(b) {
+		return false
+	}
+	return s.IsSubset(b)
+}
+
+// IsSubset reports whether every element of s is also in b.
+func (s Set[K]) IsSubset(b Set[K]) bool {
+	if len(s) > len(b) {
+		return false
+	}
+	for k := range s {
+		if !b.Has(k) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s Set[K]) Any(predicate func(e K) bool) bool {
+	for k := range s {
+		if predicate(k) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s Set[K]) All(predicate func(e K) bool) bool {
+	for k := range s {
+		if !predicate(k) {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter returns a new set with all elements matching the predicate.
+func (s Set[K]) Filter(predicate func(e K) bool) Set[K] {
+	n := New[K]()
+	for k := range s {
+		if predicate(k) {
+			n.Put(k)
+		}
+	}
+	return n
+}
+
 func (s Set[K]) Keys() []K {
 	var keys []K
 	for k := range s {
@@ -42,19 +107,64 @@ func (s Set[K]) Keys() []K {
 	return keys
 }
 
+// SortedKeys returns the elements of the set ordered by the given comparator.
+func (s Set[K]) SortedKeys(cmp func(a, b K) int) []K {
+	keys := s.Keys()
+	slices.SortFunc(keys, cmp)
+	return keys
+}
+
+// String renders the set with its elements sorted, by value if the key type
+// is ordered and by their formatted representation otherwise.
 func (s Set[K]) String() string {
 	if len(s) == 0 {
 		return "{}"
 	}
 
-	var keys []string
-	for _, k := range s.Keys() {
+	values := s.Keys()
+	ordered := isOrdered(reflect.TypeOf((*K)(nil)).Elem())
+	if ordered {
+		slices.SortFunc(values, compareOrdered[K])
+	}
+
+	keys := make([]string, 0, len(values))
+	for _, k := range values {
 		keys = append(keys, fmt.Sprintf("%v", k))
 	}
 
+	if !ordered {
+		slices.Sort(keys)
+	}
+
 	return "{ " + strings.Join(keys, ", ") + " }"
 }
 
+func isOrdered(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.String:
+		return true
+	}
+	return false
+}
+
+// compareOrdered compares two keys of an ordered kind, see isOrdered
+func compareOrdered[K comparable](a, b K) int {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	switch va.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(va.Int(), vb.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(va.Uint(), vb.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(va.Float(), vb.Float())
+	case reflect.String:
+		return cmp.Compare(va.String(), vb.String())
+	}
+	panic(fmt.Errorf("not an ordered kind: %s", va.Kind()))
+}
+
 func New[K comparable]() Set[K] {
 	return make(Set[K])
 }
@@ -85,3 +195,26 @@ func Union[K comparable](a, b Set[K]) Set[K] {
 	}
 	return union
 }
+
+// Difference returns a new set with all elements of a that are not in b.
+func Difference[K comparable](a, b Set[K]) Set[K] {
+	diff := New[K]()
+	for k := range a {
+		if !b.Has(k) {
+			diff.Put(k)
+		}
+	}
+	return diff
+}
+
+// SymmetricDifference returns a new set with all elements that are in
+// exactly one of a and b.
+func SymmetricDifference[K comparable](a, b Set[K]) Set[K] {
+	diff := Difference(a, b)
+	for k := range b {
+		if !a.Has(k) {
+			diff.Put(k)
+		}
+	}
+	return diff
+}
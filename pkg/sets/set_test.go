@@ -2,6 +2,7 @@ package sets
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"aoc/pkg/be"
@@ -39,3 +40,81 @@ func TestString(t *testing.T) {
 
 	fmt.Println(set)
 }
+
+func TestRemove(t *testing.T) {
+	set := New[string]()
+	set.PutAll([]string{"a", "b"})
+
+	set.Remove("a")
+	set.Remove("z")
+
+	be.Equal(t, set.Has("a"), false)
+	be.Equal(t, set.Size(), 1)
+}
+
+func TestClone(t *testing.T) {
+	set := New[int]()
+	set.PutAll([]int{1, 2})
+
+	c := set.Clone()
+	c.Put(3)
+
+	be.Equal(t, set.Size(), 2)
+	be.True(t, set.IsSubset(c))
+	be.True(t, !c.IsSubset(set))
+}
+
+func TestDifference(t *testing.T) {
+	setA := New[int]()
+	setA.PutAll([]int{1, 2, 3})
+
+	setB := New[int]()
+	setB.PutAll([]int{3, 4})
+
+	d := Difference(setA, setB)
+	be.Equal(t, d.String(), "{ 1, 2 }")
+
+	sd := SymmetricDifference(setA, setB)
+	be.Equal(t, sd.String(), "{ 1, 2, 4 }")
+}
+
+func TestEqual(t *testing.T) {
+	setA := New[int]()
+	setA.PutAll([]int{1, 2, 3})
+
+	setB := New[int]()
+	setB.PutAll([]int{3, 2, 1})
+
+	be.True(t, setA.Equal(setB))
+
+	setB.Remove(1)
+	be.True(t, !setA.Equal(setB))
+}
+
+func TestPredicates(t *testing.T) {
+	set := New[int]()
+	set.PutAll([]int{2, 4, 5, 6})
+
+	even := func(e int) bool { return e%2 == 0 }
+
+	be.True(t, set.Any(even))
+	be.True(t, !set.All(even))
+	be.Equal(t, set.Filter(even).String(), "{ 2, 4, 6 }")
+}
+
+func TestSortedKeys(t *testing.T) {
+	set := New[string]()
+	set.PutAll([]string{"c", "a", "b"})
+
+	keys := set.SortedKeys(strings.Compare)
+	be.Equal(t, strings.Join(keys, ""), "abc")
+}
+
+func TestStringDeterministic(t *testing.T) {
+	type point struct{ X, Y int }
+
+	set := New[point]()
+	set.PutAll([]point{{2, 1}, {1, 2}, {1, 1}})
+
+	be.Equal(t, set.String(), "{ {1 1}, {1 2}, {2 1} }")
+}
@@ -0,0 +1,180 @@
+package spatial
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"aoc/pkg/vec"
+)
+
+// Hash is a uniform grid of buckets, each covering a cube of CellSize in
+// every dimension. It suits dense point sets with small coordinates, where
+// a query only has to visit a handful of buckets.
+type Hash[P Point] struct {
+	metric   Metric
+	dims     int
+	cellSize int
+	coords   []coords
+	cells    map[coords][]int
+	// bounding box of all occupied cells
+	minCell, maxCell coords
+}
+
+// NewHash buckets the points into cells of cellSize, which must be
+// positive.
+func NewHash[P Point](points []P, cellSize int, metric Metric) *Hash[P] {
+	if cellSize <= 0 {
+		panic(fmt.Errorf("spatial hash needs a positive cell size, got %d", cellSize))
+	}
+	h := &Hash[P]{
+		metric:   metric,
+		dims:     dims[P](),
+		cellSize: cellSize,
+		coords:   make([]coords, len(points)),
+		cells:    map[coords][]int{},
+	}
+	for i, p := range points {
+		c := toCoords(p)
+		h.coords[i] = c
+		cell := h.cellOf(c)
+		h.cells[cell] = append(h.cells[cell], i)
+		for a := range cell {
+			if i == 0 || cell[a] < h.minCell[a] {
+				h.minCell[a] = cell[a]
+			}
+			if i == 0 || cell[a] > h.maxCell[a] {
+				h.maxCell[a] = cell[a]
+			}
+		}
+	}
+	return h
+}
+
+func (h *Hash[P]) Len() int {
+	return len(h.coords)
+}
+
+func (h *Hash[P]) cellOf(c coords) coords {
+	var cell coords
+	for a := 0; a < h.dims; a++ {
+		cell[a] = vec.FloorDiv(c[a], h.cellSize)
+	}
+	return cell
+}
+
+// visit calls fn with the indices of every bucket within the cells from
+// and to, both inclusive.
+func (h *Hash[P]) visit(from, to coords, fn func(i int)) {
+	for a := range from {
+		from[a] = max(from[a], h.minCell[a])
+		to[a] = min(to[a], h.maxCell[a])
+		if from[a] > to[a] {
+			return
+		}
+	}
+	var cell coords
+	for cell[2] = from[2]; cell[2] <= to[2]; cell[2]++ {
+		for cell[1] = from[1]; cell[1] <= to[1]; cell[1]++ {
+			for cell[0] = from[0]; cell[0] <= to[0]; cell[0]++ {
+				for _, i := range h.cells[cell] {
+					fn(i)
+				}
+			}
+		}
+	}
+}
+
+// visitRing calls fn with the indices of all buckets at a chebyshev distance
+// of exactly r cells from center.
+func (h *Hash[P]) visitRing(center coords, r int, fn func(i int)) {
+	if r == 0 {
+		h.visit(center, center, fn)
+		return
+	}
+	var from, to coords
+	for a := 0; a < h.dims; a++ {
+		from[a], to[a] = center[a]-r, center[a]+r
+	}
+	// a shell is the two faces on each axis, minus what earlier axes took
+	for a := 0; a < h.dims; a++ {
+		lo, hi := from, to
+		lo[a], hi[a] = from[a], from[a]
+		h.visit(lo, hi, fn)
+		lo[a], hi[a] = to[a], to[a]
+		h.visit(lo, hi, fn)
+		from[a]++
+		to[a]--
+	}
+}
+
+// Nearest returns the k points closest to q, closest first. Ties are broken
+// by index. It searches outwards ring by ring until no closer point can
+// remain.
+func (h *Hash[P]) Nearest(q P, k int) []Neighbor {
+	n := &nearest{k: min(k, h.Len())}
+	if n.k <= 0 {
+		return nil
+	}
+	c := toCoords(q)
+	center := h.cellOf(c)
+
+	maxRing := 0
+	for a := 0; a < h.dims; a++ {
+		maxRing = max(maxRing, center[a]-h.minCell[a], h.maxCell[a]-center[a])
+	}
+	for r := 0; r <= maxRing; r++ {
+		// points in ring r are at least r-1 whole cells away on some axis
+		if r > 0 && n.full() && h.metric.axisDist((r-1)*h.cellSize+1) > n.worst() {
+			break
+		}
+		h.visitRing(center, r, func(i int) {
+			n.offer(Neighbor{Index: i, Distance: h.metric.dist(c, h.coords[i])})
+		})
+	}
+	return n.neighbors
+}
+
+// Within returns all points with a distance of at most radius to q,
+// closest first.
+func (h *Hash[P]) Within(q P, radius int) []Neighbor {
+	c := toCoords(q)
+
+	// widest reach along a single axis
+	reach := radius
+	if h.metric == SquaredEuclidean {
+		reach = int(math.Sqrt(float64(radius))) + 1
+	}
+
+	var from, to coords
+	for a := 0; a < 3; a++ {
+		from[a], to[a] = c[a], c[a]
+		if a < h.dims {
+			from[a], to[a] = c[a]-reach, c[a]+reach
+		}
+	}
+
+	var found []Neighbor
+	h.visit(h.cellOf(from), h.cellOf(to), func(i int) {
+		if d := h.metric.dist(c, h.coords[i]); d <= radius {
+			found = append(found, Neighbor{Index: i, Distance: d})
+		}
+	})
+	slices.SortFunc(found, compareNeighbors)
+	return found
+}
+
+// InBox returns the indices of all points within the box spanned by from
+// and to, both inclusive, in ascending order.
+func (h *Hash[P]) InBox(from, to P) []int {
+	lo, hi := toCoords(from), toCoords(to)
+
+	var found []int
+	h.visit(h.cellOf(lo), h.cellOf(hi), func(i int) {
+		if inBox(h.coords[i], lo, hi) {
+			found = append(found, i)
+		}
+	})
+	slices.Sort(found)
+	return found
+}
@@ -0,0 +1,135 @@
+package spatial
+
+import (
+	"cmp"
+	"slices"
+)
+
+// KDTree is a static k-d tree over a set of points.
+//
+// https://en.wikipedia.org/wiki/K-d_tree
+type KDTree[P Point] struct {
+	metric Metric
+	dims   int
+	coords []coords
+	// order is the tree in implicit layout, the root of each subrange is
+	// its middle element, split on axis depth%dims
+	order []int
+}
+
+func NewKDTree[P Point](points []P, metric Metric) *KDTree[P] {
+	t := &KDTree[P]{
+		metric: metric,
+		dims:   dims[P](),
+		coords: make([]coords, len(points)),
+		order:  make([]int, len(points)),
+	}
+	for i, p := range points {
+		t.coords[i] = toCoords(p)
+		t.order[i] = i
+	}
+	t.build(t.order, 0)
+	return t
+}
+
+func (t *KDTree[P]) build(order []int, depth int) {
+	if len(order) <= 1 {
+		return
+	}
+	axis := depth % t.dims
+	slices.SortFunc(order, func(a, b int) int {
+		return cmp.Compare(t.coords[a][axis], t.coords[b][axis])
+	})
+	mid := len(order) / 2
+	t.build(order[:mid], depth+1)
+	t.build(order[mid+1:], depth+1)
+}
+
+func (t *KDTree[P]) Len() int {
+	return len(t.order)
+}
+
+// Nearest returns the k points closest to q, closest first. Ties are broken
+// by index.
+func (t *KDTree[P]) Nearest(q P, k int) []Neighbor {
+	n := &nearest{k: min(k, t.Len())}
+	t.nearest(t.order, 0, toCoords(q), n)
+	return n.neighbors
+}
+
+func (t *KDTree[P]) nearest(order []int, depth int, q coords, n *nearest) {
+	if len(order) == 0 {
+		return
+	}
+	mid := len(order) / 2
+	i := order[mid]
+	n.offer(Neighbor{Index: i, Distance: t.metric.dist(q, t.coords[i])})
+
+	axis := depth % t.dims
+	d := q[axis] - t.coords[i][axis]
+	near, far := order[:mid], order[mid+1:]
+	if d > 0 {
+		near, far = far, near
+	}
+	t.nearest(near, depth+1, q, n)
+	if !n.full() || t.metric.axisDist(d) <= n.worst() {
+		t.nearest(far, depth+1, q, n)
+	}
+}
+
+// Within returns all points with a distance of at most radius to q,
+// closest first.
+func (t *KDTree[P]) Within(q P, radius int) []Neighbor {
+	var found []Neighbor
+	t.within(t.order, 0, toCoords(q), radius, &found)
+	slices.SortFunc(found, compareNeighbors)
+	return found
+}
+
+func (t *KDTree[P]) within(order []int, depth int, q coords, radius int, found *[]Neighbor) {
+	if len(order) == 0 {
+		return
+	}
+	mid := len(order) / 2
+	i := order[mid]
+	if d := t.metric.dist(q, t.coords[i]); d <= radius {
+		*found = append(*found, Neighbor{Index: i, Distance: d})
+	}
+
+	axis := depth % t.dims
+	d := q[axis] - t.coords[i][axis]
+	if d <= 0 || t.metric.axisDist(d) <= radius {
+		t.within(order[:mid], depth+1, q, radius, found)
+	}
+	if d >= 0 || t.metric.axisDist(d) <= radius {
+		t.within(order[mid+1:], depth+1, q, radius, found)
+	}
+}
+
+// InBox returns the indices of all points within the box spanned by from
+// and to, both inclusive, in ascending order.
+func (t *KDTree[P]) InBox(from, to P) []int {
+	var found []int
+	t.inBox(t.order, 0, toCoords(from), toCoords(to), &found)
+	slices.Sort(found)
+	return found
+}
+
+func (t *KDTree[P]) inBox(order []int, depth int, from, to coords, found *[]int) {
+	if len(order) == 0 {
+		return
+	}
+	mid := len(order) / 2
+	i := order[mid]
+	if inBox(t.coords[i], from, to) {
+		*found = append(*found, i)
+	}
+
+	axis := depth % t.dims
+	if from[axis] <= t.coords[i][axis] {
+		t.inBox(order[:mid], depth+1, from, to, found)
+	}
+	if to[axis] >= t.coords[i][axis] {
+		t.inBox(order[mid+1:], depth+1, from, to, found)
+	}
+}
@@ -0,0 +1,110 @@
+// Package spatial has indexes over integer points for nearest neighbour,
+// radius and box queries that don't compare against every point.
+package spatial
+
+import (
+	"cmp"
+	"slices"
+
+	"aoc/pkg/vec"
+)
+
+// Point is a grid point in two or three dimensions.
+type Point interface {
+	vec.Vec2i | vec.Vec3i
+}
+
+// Metric is the distance used for queries. For euclidean distances the
+// squared length is used, it keeps the order and stays an integer.
+type Metric uint8
+
+const (
+	SquaredEuclidean Metric = iota
+	Manhattan
+)
+
+// Neighbor is a query result, a point by its index in the indexed points.
+type Neighbor struct {
+	Index    int
+	Distance int
+}
+
+type coords [3]int
+
+// toCoords flattens p, 2D points get a Z of zero.
+func toCoords[P Point](p P) coords {
+	switch v := any(p).(type) {
+	case vec.Vec2i:
+		return coords{v.X, v.Y, 0}
+	case vec.Vec3i:
+		return coords{v.X, v.Y, v.Z}
+	}
+	panic("unreachable")
+}
+
+func dims[P Point]() int {
+	var p P
+	if _, ok := any(p).(vec.Vec2i); ok {
+		return 2
+	}
+	return 3
+}
+
+func (m Metric) dist(a, b coords) int {
+	sum := 0
+	for i := range a {
+		sum += m.axisDist(a[i] - b[i])
+	}
+	return sum
+}
+
+// axisDist is the contribution of a difference d along a single axis, a
+// lower bound for the distance of points at least that far apart on it.
+func (m Metric) axisDist(d int) int {
+	if m == Manhattan {
+		return max(d, -d)
+	}
+	return d * d
+}
+
+func inBox(p, from, to coords) bool {
+	for i := range p {
+		if p[i] < from[i] || p[i] > to[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func compareNeighbors(a, b Neighbor) int {
+	if r := cmp.Compare(a.Distance, b.Distance); r != 0 {
+		return r
+	}
+	return cmp.Compare(a.Index, b.Index)
+}
+
+// nearest collects the k closest neighbours seen, in order.
+type nearest struct {
+	k         int
+	neighbors []Neighbor
+}
+
+func (n *nearest) full() bool {
+	return len(n.neighbors) == n.k
+}
+
+// worst returns the distance a candidate has to beat once full.
+func (n *nearest) worst() int {
+	return n.neighbors[len(n.neighbors)-1].Distance
+}
+
+func (n *nearest) offer(c Neighbor) {
+	if n.k <= 0 || (n.full() && compareNeighbors(c, n.neighbors[n.k-1]) >= 0) {
+		return
+	}
+	i, _ := slices.BinarySearchFunc(n.neighbors, c, compareNeighbors)
+	if n.full() {
+		n.neighbors = n.neighbors[:n.k-1]
+	}
+	n.neighbors = slices.Insert(n.neighbors, i, c)
+}
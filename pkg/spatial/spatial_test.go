@@ -0,0 +1,175 @@
+package spatial
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"aoc/pkg/be"
+	"aoc/pkg/vec"
+)
+
+type index[P Point] interface {
+	Nearest(q P, k int) []Neighbor
+	Within(q P, radius int) []Neighbor
+	InBox(from, to P) []int
+}
+
+func randomVec3i(r *rand.Rand, n, size int) []vec.Vec3i {
+	points := make([]vec.Vec3i, n)
+	for i := range points {
+		points[i] = vec.Vec3i{X: r.Intn(size), Y: r.Intn(size), Z: r.Intn(size)}
+	}
+	return points
+}
+
+func randomVec2i(r *rand.Rand, n, size int) []vec.Vec2i {
+	points := make([]vec.Vec2i, n)
+	for i := range points {
+		points[i] = vec.Vec2i{X: r.Intn(size) - size/2, Y: r.Intn(size) - size/2}
+	}
+	return points
+}
+
+// bruteForce ranks all points by their distance to q
+func bruteForce[P Point](points []P, q P, m Metric) []Neighbor {
+	c := toCoords(q)
+	neighbors := make([]Neighbor, len(points))
+	for i, p := range points {
+		neighbors[i] = Neighbor{Index: i, Distance: m.dist(c, toCoords(p))}
+	}
+	slices.SortFunc(neighbors, compareNeighbors)
+	return neighbors
+}
+
+func checkIndex[P Point](t *testing.T, idx index[P], points, queries []P, m Metric) {
+	t.Helper()
+	for _, q := range queries {
+		all := bruteForce(points, q, m)
+
+		be.True(t, slices.Equal(idx.Nearest(q, 7), all[:7]))
+		be.True(t, slices.Equal(idx.Nearest(q, 1), all[:1]))
+
+		radius := all[20].Distance
+		want := slices.DeleteFunc(slices.Clone(all), func(n Neighbor) bool {
+			return n.Distance > radius
+		})
+		be.True(t, slices.Equal(idx.Within(q, radius), want))
+	}
+}
+
+func TestKDTree(t *testing.T) {
+	r := rand.New(rand.NewSource(8))
+	points := randomVec3i(r, 500, 1000)
+	queries := randomVec3i(r, 50, 1200)
+
+	checkIndex[vec.Vec3i](t, NewKDTree(points, SquaredEuclidean), points, queries, SquaredEuclidean)
+	checkIndex[vec.Vec3i](t, NewKDTree(points, Manhattan), points, queries, Manhattan)
+
+	points2 := randomVec2i(r, 500, 100)
+	queries2 := randomVec2i(r, 50, 120)
+	checkIndex[vec.Vec2i](t, NewKDTree(points2, SquaredEuclidean), points2, queries2, SquaredEuclidean)
+	checkIndex[vec.Vec2i](t, NewKDTree(points2, Manhattan), points2, queries2, Manhattan)
+}
+
+func TestHash(t *testing.T) {
+	r := rand.New(rand.NewSource(8))
+	points := randomVec3i(r, 500, 1000)
+	queries := randomVec3i(r, 50, 1200)
+
+	checkIndex[vec.Vec3i](t, NewHash(points, 100, SquaredEuclidean), points, queries, SquaredEuclidean)
+	checkIndex[vec.Vec3i](t, NewHash(points, 64, Manhattan), points, queries, Manhattan)
+
+	points2 := randomVec2i(r, 500, 100)
+	queries2 := randomVec2i(r, 50, 120)
+	checkIndex[vec.Vec2i](t, NewHash(points2, 7, SquaredEuclidean), points2, queries2, SquaredEuclidean)
+	checkIndex[vec.Vec2i](t, NewHash(points2, 10, Manhattan), points2, queries2, Manhattan)
+}
+
+func TestInBox(t *testing.T) {
+	points := []vec.Vec2i{{X: 0, Y: 0}, {X: 5, Y: 5}, {X: -3, Y: 2}, {X: 4, Y: -1}, {X: 2, Y: 2}}
+	from, to := vec.Vec2i{X: -3, Y: 0}, vec.Vec2i{X: 4, Y: 4}
+
+	be.True(t, slices.Equal(NewKDTree(points, Manhattan).InBox(from, to), []int{0, 2, 4}))
+	be.True(t, slices.Equal(NewHash(points, 2, Manhattan).InBox(from, to), []int{0, 2, 4}))
+}
+
+func TestKDTree_ExtremeCoordinates(t *testing.T) {
+	// differences of these overflow int
+	points := []vec.Vec2i{
+		{X: math.MaxInt, Y: 0}, {X: math.MinInt, Y: 1}, {X: 0, Y: 2},
+		{X: math.MaxInt - 1, Y: 3}, {X: math.MinInt + 1, Y: 4}, {X: 1, Y: 5},
+	}
+	tree := NewKDTree(points, Manhattan)
+	be.True(t, slices.Equal(tree.InBox(vec.Vec2i{X: 0, Y: 0}, vec.Vec2i{X: 1, Y: 5}), []int{2, 5}))
+	be.True(t, slices.Equal(tree.InBox(vec.Vec2i{X: math.MinInt, Y: 0}, vec.Vec2i{X: -1, Y: 5}), []int{1, 4}))
+}
+
+func TestNewHash_CellSize(t *testing.T) {
+	defer func() {
+		be.True(t, recover() != nil)
+	}()
+	NewHash([]vec.Vec2i{{X: 1, Y: 1}}, 0, Manhattan)
+	t.Fatal("no panic for a cell size of 0")
+}
+
+func TestNearest_Few(t *testing.T) {
+	points := []vec.Vec3i{{X: 1, Y: 2, Z: 3}, {X: 1, Y: 2, Z: 4}}
+
+	be.Equal(t, len(NewKDTree(points, Manhattan).Nearest(vec.Vec3i{}, 5)), 2)
+	be.Equal(t, len(NewHash(points, 4, Manhattan).Nearest(vec.Vec3i{}, 5)), 2)
+	be.Equal(t, len(NewKDTree([]vec.Vec3i{}, Manhattan).Nearest(vec.Vec3i{}, 5)), 0)
+	be.Equal(t, len(NewHash([]vec.Vec3i{}, 4, Manhattan).Nearest(vec.Vec3i{}, 5)), 0)
+}
+
+// the junction boxes of 2025 day 8 are a thousand points spread over a
+// cube of about 100000
+func benchmarkPoints() []vec.Vec3i {
+	return randomVec3i(rand.New(rand.NewSource(2025)), 1000, 100000)
+}
+
+func BenchmarkNearest_BruteForce(b *testing.B) {
+	points := benchmarkPoints()
+	for i := 0; i < b.N; i++ {
+		for _, q := range points {
+			_ = bruteForce(points, q, SquaredEuclidean)[:10]
+		}
+	}
+}
+
+func BenchmarkNearest_KDTree(b *testing.B) {
+	points := benchmarkPoints()
+	tree := NewKDTree(points, SquaredEuclidean)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, q := range points {
+			tree.Nearest(q, 10)
+		}
+	}
+}
+
+func BenchmarkNearest_Hash(b *testing.B) {
+	points := benchmarkPoints()
+	hash := NewHash(points, 10000, SquaredEuclidean)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, q := range points {
+			hash.Nearest(q, 10)
+		}
+	}
+}
+
+func BenchmarkBuild_KDTree(b *testing.B) {
+	points := benchmarkPoints()
+	for i := 0; i < b.N; i++ {
+		NewKDTree(points, SquaredEuclidean)
+	}
+}
+
+func BenchmarkBuild_Hash(b *testing.B) {
+	points := benchmarkPoints()
+	for i := 0; i < b.N; i++ {
+		NewHash(points, 10000, SquaredEuclidean)
+	}
+}
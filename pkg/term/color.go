@@ -2,9 +2,13 @@ package term
 
 import (
 	"fmt"
+	"image/color"
 	"io"
+	"strings"
 )
 
+// Color is an escape sequence that sets the colour or style of the text
+// that follows it. Colours and styles combine by concatenation, see Style.
 type Color string
 
 var (
@@ -19,11 +23,74 @@ var (
 	ColorWhite   Color = "\033[97m"
 )
 
+var (
+	StyleBold      Color = "\033[1m"
+	StyleDim       Color = "\033[2m"
+	StyleUnderline Color = "\033[4m"
+)
+
+// Color256 returns the foreground colour n of the 256 colour palette.
+func Color256(n uint8) Color {
+	return Color(fmt.Sprintf("\033[38;5;%dm", n))
+}
+
+// Background256 returns the background colour n of the 256 colour palette.
+func Background256(n uint8) Color {
+	return Color(fmt.Sprintf("\033[48;5;%dm", n))
+}
+
+// RGB returns a 24-bit foreground colour.
+func RGB(r, g, b uint8) Color {
+	return Color(fmt.Sprintf("\033[38;2;%d;%d;%dm", r, g, b))
+}
+
+// BackgroundRGB returns a 24-bit background colour.
+func BackgroundRGB(r, g, b uint8) Color {
+	return Color(fmt.Sprintf("\033[48;2;%d;%d;%dm", r, g, b))
+}
+
+// FromColor returns c as 24-bit foreground colour, ignoring its alpha.
+func FromColor(c color.Color) Color {
+	rgba := color.RGBAModel.Convert(c).(color.RGBA)
+	return RGB(rgba.R, rgba.G, rgba.B)
+}
+
+// BackgroundFromColor returns c as 24-bit background colour, ignoring its
+// alpha.
+func BackgroundFromColor(c color.Color) Color {
+	rgba := color.RGBAModel.Convert(c).(color.RGBA)
+	return BackgroundRGB(rgba.R, rgba.G, rgba.B)
+}
+
+// Style combines colours and styles, e.g. a bold foreground on a
+// background.
+func Style(colors ...Color) Color {
+	var buf strings.Builder
+	for _, c := range colors {
+		buf.WriteString(string(c))
+	}
+	return Color(buf.String())
+}
+
+// StringInColor returns s in colour c, or just s if colour is disabled for
+// stdout.
 func StringInColor(s string, c Color) string {
-	return fmt.Sprintf("%s%s%s", c, s, ColorReset)
+	if !Enabled() {
+		return s
+	}
+	return colored(s, c)
 }
 
+// WriteInColor writes s in colour c, or just s if colour is disabled for w.
 func WriteInColor(w io.Writer, s string, c Color) error {
-	_, err := fmt.Fprintf(w, "%s%s%s", c, s, ColorReset)
+	if !enabledFor(w) {
+		_, err := io.WriteString(w, s)
+		return err
+	}
+	_, err := io.WriteString(w, colored(s, c))
 	return err
 }
+
+func colored(s string, c Color) string {
+	return fmt.Sprintf("%s%s%s", c, s, ColorReset)
+}
@@ -0,0 +1,94 @@
+package term
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Escape sequences for clearing and switching screens.
+const (
+	ClearScreen    = "\033[2J\033[H"
+	ClearLine      = "\033[2K\r"
+	ClearToEnd     = "\033[J"
+	ClearLineToEnd = "\033[K"
+	HideCursor     = "\033[?25l"
+	ShowCursor     = "\033[?25h"
+	EnterAltScreen = "\033[?1049h"
+	ExitAltScreen  = "\033[?1049l"
+)
+
+// CursorTo moves the cursor to row and column, counted from 1.
+func CursorTo(row, col int) string {
+	return fmt.Sprintf("\033[%d;%dH", row, col)
+}
+
+// CursorUp moves the cursor n lines up.
+func CursorUp(n int) string {
+	return fmt.Sprintf("\033[%dA", n)
+}
+
+// CursorDown moves the cursor n lines down.
+func CursorDown(n int) string {
+	return fmt.Sprintf("\033[%dB", n)
+}
+
+// CursorForward moves the cursor n columns right.
+func CursorForward(n int) string {
+	return fmt.Sprintf("\033[%dC", n)
+}
+
+// CursorBack moves the cursor n columns left.
+func CursorBack(n int) string {
+	return fmt.Sprintf("\033[%dD", n)
+}
+
+// Screen draws frames in place on the alternate screen, so a simulation
+// can be watched without scrolling. If colour is disabled for the writer,
+// e.g. a pipe, frames are written one after the other instead.
+type Screen struct {
+	w    io.Writer
+	live bool
+}
+
+// NewScreen switches w to the alternate screen and hides the cursor until
+// Close.
+func NewScreen(w io.Writer) (*Screen, error) {
+	s := &Screen{w: w, live: enabledFor(w)}
+	if !s.live {
+		return s, nil
+	}
+	_, err := io.WriteString(w, EnterAltScreen+HideCursor+ClearScreen)
+	return s, err
+}
+
+// Draw replaces the previous frame with frame.
+func (s *Screen) Draw(frame string) error {
+	frame = strings.TrimSuffix(frame, "\n")
+	if !s.live {
+		_, err := fmt.Fprintf(s.w, "%s\n\n", frame)
+		return err
+	}
+
+	var buf strings.Builder
+	buf.WriteString(CursorTo(1, 1))
+	for i, line := range strings.Split(frame, "\n") {
+		if i > 0 {
+			buf.WriteString("\r\n")
+		}
+		buf.WriteString(line)
+		buf.WriteString(ClearLineToEnd)
+	}
+	buf.WriteString("\r\n" + ClearToEnd)
+	_, err := io.WriteString(s.w, buf.String())
+	return err
+}
+
+// Close shows the cursor again and returns to the main screen.
+func (s *Screen) Close() error {
+	if !s.live {
+		return nil
+	}
+	_, err := io.WriteString(s.w, ShowCursor+ExitAltScreen)
+	return err
+}
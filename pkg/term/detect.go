@@ -0,0 +1,54 @@
+package term
+
+import (
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	detectOnce sync.Once
+	enabled    atomic.Bool
+	overridden atomic.Bool
+)
+
+// Enabled reports whether colours and cursor control are written to
+// stdout. It is detected once: stdout has to be a terminal and NO_COLOR
+// unset or empty.
+func Enabled() bool {
+	detectOnce.Do(func() {
+		enabled.Store(detect(os.Stdout, os.Getenv("NO_COLOR")))
+	})
+	return enabled.Load()
+}
+
+// SetEnabled overrides the detection for all writers, e.g. to force
+// colours into a pipe.
+func SetEnabled(on bool) {
+	detectOnce.Do(func() {})
+	overridden.Store(true)
+	enabled.Store(on)
+}
+
+// enabledFor reports whether colours and cursor control are written to w.
+// Files other than stdout are detected on their own, other writers such as
+// buffers follow Enabled.
+func enabledFor(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok || f == os.Stdout || overridden.Load() {
+		return Enabled()
+	}
+	return detect(f, os.Getenv("NO_COLOR"))
+}
+
+func detect(f *os.File, noColor string) bool {
+	return noColor == "" && isTerminal(f)
+}
+
+// isTerminal reports whether f is a character device, which is as close
+// as the standard library gets to isatty.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
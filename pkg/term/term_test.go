@@ -0,0 +1,122 @@
+package term
+
+import (
+	"bytes"
+	"image/color"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"aoc/pkg/be"
+)
+
+// setEnabled overrides the detection for the duration of the test.
+func setEnabled(t *testing.T, on bool) {
+	previous, wasOverridden := Enabled(), overridden.Load()
+	SetEnabled(on)
+	t.Cleanup(func() {
+		enabled.Store(previous)
+		overridden.Store(wasOverridden)
+	})
+}
+
+func TestColors(t *testing.T) {
+	be.Equal(t, Color256(208), Color("\033[38;5;208m"))
+	be.Equal(t, Background256(17), Color("\033[48;5;17m"))
+	be.Equal(t, RGB(255, 128, 0), Color("\033[38;2;255;128;0m"))
+	be.Equal(t, BackgroundRGB(0, 0, 64), Color("\033[48;2;0;0;64m"))
+	be.Equal(t, FromColor(color.RGBA{1, 2, 3, 255}), RGB(1, 2, 3))
+	be.Equal(t, BackgroundFromColor(color.Gray{Y: 9}), BackgroundRGB(9, 9, 9))
+	be.Equal(t, Style(StyleBold, ColorRed), Color("\033[1m\033[31m"))
+}
+
+func TestStringInColor(t *testing.T) {
+	setEnabled(t, true)
+	be.Equal(t, StringInColor("x", StyleUnderline), "\033[4mx\033[0m")
+
+	var buf bytes.Buffer
+	be.NoError(t, WriteInColor(&buf, "y", ColorGreen))
+	be.Equal(t, buf.String(), "\033[32my\033[0m")
+
+	SetEnabled(false)
+	be.Equal(t, StringInColor("x", StyleUnderline), "x")
+
+	buf.Reset()
+	be.NoError(t, WriteInColor(&buf, "y", ColorGreen))
+	be.Equal(t, buf.String(), "y")
+}
+
+func TestDetect(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "out"))
+	be.NoError(t, err)
+	defer f.Close()
+	be.True(t, !detect(f, ""))
+
+	tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0)
+	if err != nil {
+		t.Skip("no terminal")
+	}
+	defer tty.Close()
+	be.True(t, detect(tty, ""))
+	be.True(t, !detect(tty, "1"))
+}
+
+func TestEnabledFor(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "out"))
+	be.NoError(t, err)
+	defer f.Close()
+
+	t.Run("detected", func(t *testing.T) {
+		setEnabled(t, true)
+		overridden.Store(false)
+		be.True(t, !enabledFor(f))
+		be.True(t, enabledFor(&bytes.Buffer{}))
+
+		be.NoError(t, WriteInColor(f, "y", ColorGreen))
+		content, err := os.ReadFile(f.Name())
+		be.NoError(t, err)
+		be.Equal(t, string(content), "y")
+	})
+
+	t.Run("overridden", func(t *testing.T) {
+		setEnabled(t, true)
+		be.True(t, enabledFor(f))
+	})
+}
+
+func TestCursor(t *testing.T) {
+	be.Equal(t, CursorTo(3, 7), "\033[3;7H")
+	be.Equal(t, CursorUp(2), "\033[2A")
+	be.Equal(t, CursorDown(1), "\033[1B")
+	be.Equal(t, CursorForward(4), "\033[4C")
+	be.Equal(t, CursorBack(5), "\033[5D")
+}
+
+func TestScreen(t *testing.T) {
+	setEnabled(t, true)
+
+	var buf bytes.Buffer
+	s, err := NewScreen(&buf)
+	be.NoError(t, err)
+	be.Equal(t, buf.String(), EnterAltScreen+HideCursor+ClearScreen)
+
+	buf.Reset()
+	be.NoError(t, s.Draw("ab\ncd\n"))
+	be.Equal(t, buf.String(), "\033[1;1Hab\033[K\r\ncd\033[K\r\n\033[J")
+
+	buf.Reset()
+	be.NoError(t, s.Close())
+	be.Equal(t, buf.String(), ShowCursor+ExitAltScreen)
+}
+
+func TestScreenDisabled(t *testing.T) {
+	setEnabled(t, false)
+
+	var buf bytes.Buffer
+	s, err := NewScreen(&buf)
+	be.NoError(t, err)
+	be.NoError(t, s.Draw("ab\ncd\n"))
+	be.NoError(t, s.Draw("ef"))
+	be.NoError(t, s.Close())
+	be.Equal(t, buf.String(), "ab\ncd\n\nef\n\n")
+}
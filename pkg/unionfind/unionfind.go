@@ -0,0 +1,196 @@
+package unionfind
+
+// https://en.wikipedia.org/wiki/Disjoint-set_data_structure
+
+// UnionFind is a disjoint-set forest over arbitrary comparable keys. Keys are
+// added lazily as singletons the first time they are seen.
+type UnionFind[K comparable] struct {
+	index map[K]int
+	keys  []K
+	dense *Dense
+}
+
+func New[K comparable]() *UnionFind[K] {
+	return &UnionFind[K]{
+		index: make(map[K]int),
+		dense: NewDense(0),
+	}
+}
+
+// NewFrom creates a forest with every key in its own component.
+func NewFrom[K comparable](keys []K) *UnionFind[K] {
+	u := New[K]()
+	for _, k := range keys {
+		u.Add(k)
+	}
+	return u
+}
+
+// Add inserts k as a singleton if it is not yet known.
+func (u *UnionFind[K]) Add(k K) {
+	u.id(k)
+}
+
+func (u *UnionFind[K]) id(k K) int {
+	if i, ok := u.index[k]; ok {
+		return i
+	}
+	i := u.dense.grow()
+	u.index[k] = i
+	u.keys = append(u.keys, k)
+	return i
+}
+
+// Find returns the representative key of the component containing k.
+func (u *UnionFind[K]) Find(k K) K {
+	return u.keys[u.dense.Find(u.id(k))]
+}
+
+// Union merges the components of a and b, returns false if they were
+// already connected.
+func (u *UnionFind[K]) Union(a, b K) bool {
+	return u.dense.Union(u.id(a), u.id(b))
+}
+
+func (u *UnionFind[K]) Connected(a, b K) bool {
+	return u.dense.Connected(u.id(a), u.id(b))
+}
+
+// SizeOf returns the number of keys in the component containing k.
+func (u *UnionFind[K]) SizeOf(k K) int {
+	return u.dense.SizeOf(u.id(k))
+}
+
+// Count returns the number of disjoint components.
+func (u *UnionFind[K]) Count() int {
+	return u.dense.Count()
+}
+
+// Len returns the number of keys.
+func (u *UnionFind[K]) Len() int {
+	return len(u.keys)
+}
+
+// Components returns all components, each in insertion order of its keys.
+func (u *UnionFind[K]) Components() [][]K {
+	components := u.dense.Components()
+	r := make([][]K, len(components))
+	for i, c := range components {
+		r[i] = make([]K, len(c))
+		for j, id := range c {
+			r[i][j] = u.keys[id]
+		}
+	}
+	return r
+}
+
+// ComponentSizes returns the size of every component.
+func (u *UnionFind[K]) ComponentSizes() []int {
+	return u.dense.ComponentSizes()
+}
+
+// Dense is a disjoint-set forest over the integers [0, n) with path
+// compression and union by size.
+type Dense struct {
+	parent []int
+	size   []int
+	count  int
+}
+
+func NewDense(n int) *Dense {
+	d := &Dense{
+		parent: make([]int, n),
+		size:   make([]int, n),
+		count:  n,
+	}
+	for i := range d.parent {
+		d.parent[i] = i
+		d.size[i] = 1
+	}
+	return d
+}
+
+// grow appends a new singleton and returns its index
+func (d *Dense) grow() int {
+	i := len(d.parent)
+	d.parent = append(d.parent, i)
+	d.size = append(d.size, 1)
+	d.count++
+	return i
+}
+
+func (d *Dense) Find(i int) int {
+	root := i
+	for d.parent[root] != root {
+		root = d.parent[root]
+	}
+
+	// path compression
+	for d.parent[i] != root {
+		d.parent[i], i = root, d.parent[i]
+	}
+	return root
+}
+
+// Union merges the components of a and b, returns false if they were
+// already connected.
+func (d *Dense) Union(a, b int) bool {
+	ra, rb := d.Find(a), d.Find(b)
+	if ra == rb {
+		return false
+	}
+
+	// attach the smaller tree below the larger one
+	if d.size[ra] < d.size[rb] {
+		ra, rb = rb, ra
+	}
+	d.parent[rb] = ra
+	d.size[ra] += d.size[rb]
+	d.count--
+	return true
+}
+
+func (d *Dense) Connected(a, b int) bool {
+	return d.Find(a) == d.Find(b)
+}
+
+func (d *Dense) SizeOf(i int) int {
+	return d.size[d.Find(i)]
+}
+
+func (d *Dense) Count() int {
+	return d.count
+}
+
+func (d *Dense) Len() int {
+	return len(d.parent)
+}
+
+// Components returns the members of every component, ordered by their
+// smallest index.
+func (d *Dense) Components() [][]int {
+	byRoot := make(map[int]int, d.count)
+	var components [][]int
+	for i := range d.parent {
+		root := d.Find(i)
+		c, ok := byRoot[root]
+		if !ok {
+			c = len(components)
+			byRoot[root] = c
+			components = append(components, nil)
+		}
+		components[c] = append(components[c], i)
+	}
+	return components
+}
+
+// ComponentSizes returns the size of every component.
+func (d *Dense) ComponentSizes() []int {
+	var sizes []int
+	for i := range d.parent {
+		if d.parent[i] == i {
+			sizes = append(sizes, d.size[i])
+		}
+	}
+	return sizes
+}
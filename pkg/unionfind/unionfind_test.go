@@ -0,0 +1,54 @@
+package unionfind
+
+import (
+	"slices"
+	"testing"
+
+	"aoc/pkg/be"
+)
+
+func TestUnionFind(t *testing.T) {
+	u := NewFrom([]string{"a", "b", "c", "d", "e"})
+	be.Equal(t, u.Count(), 5)
+
+	be.True(t, u.Union("a", "b"))
+	be.True(t, u.Union("c", "d"))
+	be.True(t, u.Union("b", "d"))
+	be.True(t, !u.Union("a", "c"))
+
+	be.Equal(t, u.Count(), 2)
+	be.True(t, u.Connected("a", "d"))
+	be.True(t, !u.Connected("a", "e"))
+	be.Equal(t, u.SizeOf("c"), 4)
+	be.Equal(t, u.Find("a"), u.Find("d"))
+
+	components := u.Components()
+	be.Equal(t, len(components), 2)
+	be.True(t, slices.Equal(components[0], []string{"a", "b", "c", "d"}))
+	be.True(t, slices.Equal(components[1], []string{"e"}))
+}
+
+func TestUnionFind_AddsLazily(t *testing.T) {
+	u := New[int]()
+
+	u.Union(1, 2)
+	be.Equal(t, u.Len(), 2)
+	be.Equal(t, u.Count(), 1)
+
+	be.Equal(t, u.SizeOf(3), 1)
+	be.Equal(t, u.Count(), 2)
+}
+
+func TestDense(t *testing.T) {
+	d := NewDense(6)
+	d.Union(0, 1)
+	d.Union(2, 3)
+	d.Union(3, 4)
+
+	be.Equal(t, d.Count(), 3)
+	be.Equal(t, d.SizeOf(4), 3)
+
+	sizes := d.ComponentSizes()
+	slices.Sort(sizes)
+	be.True(t, slices.Equal(sizes, []int{1, 2, 3}))
+}
@@ -4,8 +4,16 @@ import (
 	"fmt"
 )
 
+// AABB is an axis aligned bounding box, both From and To are inclusive. A box
+// with From > To in any dimension is empty.
 type AABB struct{ From, To Vec2i }
 
+// NewAABBFromSize creates a box starting at origin spanning size cells in
+// each dimension.
+func NewAABBFromSize(origin, size Vec2i) AABB {
+	return AABB{From: origin, To: origin.Add(size).Sub(Vec2i{X: 1, Y: 1})}
+}
+
 func (v AABB) Contains(p Vec2i) bool {
 	if p.X < v.From.X || p.X > v.To.X {
 		return false
@@ -18,6 +26,88 @@ func (v AABB) Contains(p Vec2i) bool {
 	return true
 }
 
+func (v AABB) IsEmpty() bool {
+	return v.From.X > v.To.X || v.From.Y > v.To.Y
+}
+
+func (v AABB) Width() int {
+	return max(0, v.To.X-v.From.X+1)
+}
+
+func (v AABB) Height() int {
+	return max(0, v.To.Y-v.From.Y+1)
+}
+
+// Size returns the number of cells in each dimension.
+func (v AABB) Size() Vec2i {
+	return Vec2i{X: v.Width(), Y: v.Height()}
+}
+
+// Area returns the number of cells within the box.
+func (v AABB) Area() int {
+	return v.Width() * v.Height()
+}
+
+// Overlaps reports whether both boxes share at least one cell.
+func (v AABB) Overlaps(o AABB) bool {
+	return !v.Intersect(o).IsEmpty()
+}
+
+// Intersect returns the box of all cells in both boxes, it is empty if they
+// don't overlap.
+func (v AABB) Intersect(o AABB) AABB {
+	return AABB{From: v.From.Max(o.From), To: v.To.Min(o.To)}
+}
+
+// Union returns the smallest box containing both boxes.
+func (v AABB) Union(o AABB) AABB {
+	if v.IsEmpty() {
+		return o
+	}
+	if o.IsEmpty() {
+		return v
+	}
+	return AABB{From: v.From.Min(o.From), To: v.To.Max(o.To)}
+}
+
+// Extend returns the smallest box containing the box and p.
+func (v AABB) Extend(p Vec2i) AABB {
+	return v.Union(AABB{From: p, To: p})
+}
+
+// Expand grows the box by n cells on every side, negative n shrinks it.
+func (v AABB) Expand(n int) AABB {
+	d := Vec2i{X: n, Y: n}
+	return AABB{From: v.From.Sub(d), To: v.To.Add(d)}
+}
+
+func (v AABB) Translate(d Vec2i) AABB {
+	return AABB{From: v.From.Add(d), To: v.To.Add(d)}
+}
+
+// Clamp returns the point within the box closest to p.
+func (v AABB) Clamp(p Vec2i) Vec2i {
+	return p.Max(v.From).Min(v.To)
+}
+
+// ForEach calls fn for every cell in row-major order.
+func (v AABB) ForEach(fn func(p Vec2i)) {
+	for y := v.From.Y; y <= v.To.Y; y++ {
+		for x := v.From.X; x <= v.To.X; x++ {
+			fn(Vec2i{X: x, Y: y})
+		}
+	}
+}
+
+// Points returns all cells in row-major order.
+func (v AABB) Points() []Vec2i {
+	points := make([]Vec2i, 0, v.Area())
+	v.ForEach(func(p Vec2i) {
+		points = append(points, p)
+	})
+	return points
+}
+
 func (v AABB) String() string {
 	return fmt.Sprintf("AABB{%v, %v}", v.From, v.To)
 }
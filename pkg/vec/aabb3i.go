@@ -0,0 +1,94 @@
+package vec
+
+import (
+	"fmt"
+)
+
+// AABB3i is an axis aligned bounding box in 3D, both From and To are
+// inclusive. A box with From > To in any dimension is empty.
+type AABB3i struct{ From, To Vec3i }
+
+// NewAABB3iFromSize creates a box starting at origin spanning size cells in
+// each dimension.
+func NewAABB3iFromSize(origin, size Vec3i) AABB3i {
+	return AABB3i{From: origin, To: origin.Add(size).Sub(Vec3i{X: 1, Y: 1, Z: 1})}
+}
+
+func (v AABB3i) Contains(p Vec3i) bool {
+	return p.X >= v.From.X && p.X <= v.To.X &&
+		p.Y >= v.From.Y && p.Y <= v.To.Y &&
+		p.Z >= v.From.Z && p.Z <= v.To.Z
+}
+
+func (v AABB3i) IsEmpty() bool {
+	return v.From.X > v.To.X || v.From.Y > v.To.Y || v.From.Z > v.To.Z
+}
+
+// Size returns the number of cells in each dimension.
+func (v AABB3i) Size() Vec3i {
+	return Vec3i{
+		X: max(0, v.To.X-v.From.X+1),
+		Y: max(0, v.To.Y-v.From.Y+1),
+		Z: max(0, v.To.Z-v.From.Z+1),
+	}
+}
+
+// Volume returns the number of cells within the box.
+func (v AABB3i) Volume() int {
+	s := v.Size()
+	return s.X * s.Y * s.Z
+}
+
+// Overlaps reports whether both boxes share at least one cell.
+func (v AABB3i) Overlaps(o AABB3i) bool {
+	return !v.Intersect(o).IsEmpty()
+}
+
+// Intersect returns the box of all cells in both boxes, it is empty if they
+// don't overlap.
+func (v AABB3i) Intersect(o AABB3i) AABB3i {
+	return AABB3i{From: v.From.Max(o.From), To: v.To.Min(o.To)}
+}
+
+// Union returns the smallest box containing both boxes.
+func (v AABB3i) Union(o AABB3i) AABB3i {
+	if v.IsEmpty() {
+		return o
+	}
+	if o.IsEmpty() {
+		return v
+	}
+	return AABB3i{From: v.From.Min(o.From), To: v.To.Max(o.To)}
+}
+
+func (v AABB3i) Translate(d Vec3i) AABB3i {
+	return AABB3i{From: v.From.Add(d), To: v.To.Add(d)}
+}
+
+// ForEach calls fn for every cell, X changing fastest and Z slowest.
+func (v AABB3i) ForEach(fn func(p Vec3i)) {
+	for z := v.From.Z; z <= v.To.Z; z++ {
+		for y := v.From.Y; y <= v.To.Y; y++ {
+			for x := v.From.X; x <= v.To.X; x++ {
+				fn(Vec3i{X: x, Y: y, Z: z})
+			}
+		}
+	}
+}
+
+func (v AABB3i) String() string {
+	return fmt.Sprintf("AABB3i{%v, %v}", v.From, v.To)
+}
+
+func BoundingBox3i(points []Vec3i) AABB3i {
+	if len(points) == 0 {
+		return AABB3i{From: Vec3i{X: 1, Y: 1, Z: 1}}
+	}
+
+	box := AABB3i{From: points[0], To: points[0]}
+	for _, p := range points[1:] {
+		box.From = box.From.Min(p)
+		box.To = box.To.Max(p)
+	}
+	return box
+}
@@ -0,0 +1,128 @@
+package vec
+
+import (
+	"fmt"
+)
+
+// Dir is one of the eight grid directions in screen coordinates, i.e. Y
+// grows downwards and N is {0,-1}. Directions are numbered clockwise
+// starting with N, which makes them usable as compact index in state keys.
+type Dir uint8
+
+const (
+	N Dir = iota
+	NE
+	E
+	SE
+	S
+	SW
+	W
+	NW
+)
+
+// Cardinals are the four orthogonal directions, clockwise starting with N.
+var Cardinals = []Dir{N, E, S, W}
+
+// Dirs are all eight directions, clockwise starting with N.
+var Dirs = []Dir{N, NE, E, SE, S, SW, W, NW}
+
+var dirVecs = [...]Vec2i{
+	N:  {X: 0, Y: -1},
+	NE: {X: 1, Y: -1},
+	E:  {X: 1, Y: 0},
+	SE: {X: 1, Y: 1},
+	S:  {X: 0, Y: 1},
+	SW: {X: -1, Y: 1},
+	W:  {X: -1, Y: 0},
+	NW: {X: -1, Y: -1},
+}
+
+var dirNames = [...]string{"N", "NE", "E", "SE", "S", "SW", "W", "NW"}
+
+// ParseDir parses a direction from one of the usual puzzle notations,
+// arrows '^>v<', 'UDLR' or compass 'NESW'.
+func ParseDir(r rune) (Dir, error) {
+	switch r {
+	case '^', 'U', 'N':
+		return N, nil
+	case '>', 'R', 'E':
+		return E, nil
+	case 'v', 'D', 'S':
+		return S, nil
+	case '<', 'L', 'W':
+		return W, nil
+	}
+	return 0, fmt.Errorf("unknown direction: %q", r)
+}
+
+// DirOf returns the direction of a unit step, false if v is none.
+func DirOf(v Vec2i) (Dir, bool) {
+	for d, dv := range dirVecs {
+		if dv == v {
+			return Dir(d), true
+		}
+	}
+	return 0, false
+}
+
+// Vec returns the unit step in this direction.
+func (d Dir) Vec() Vec2i {
+	return dirVecs[d]
+}
+
+// TurnRight rotates clockwise by 90°.
+func (d Dir) TurnRight() Dir {
+	return (d + 2) % 8
+}
+
+// TurnLeft rotates counterclockwise by 90°.
+func (d Dir) TurnLeft() Dir {
+	return (d + 6) % 8
+}
+
+// Rotate turns clockwise in steps of 45°, negative steps turn
+// counterclockwise.
+func (d Dir) Rotate(steps int) Dir {
+	return Dir(((int(d)+steps)%8 + 8) % 8)
+}
+
+func (d Dir) Reverse() Dir {
+	return (d + 4) % 8
+}
+
+func (d Dir) IsDiagonal() bool {
+	return d%2 == 1
+}
+
+// Index is the position of d in Dirs.
+func (d Dir) Index() int {
+	return int(d)
+}
+
+// Bit is a mask with only the bit of d set, e.g. to track all headings a
+// tile was already visited with in a single byte.
+func (d Dir) Bit() uint8 {
+	return 1 << d
+}
+
+func (d Dir) String() string {
+	if int(d) >= len(dirNames) {
+		return fmt.Sprintf("Dir(%d)", uint8(d))
+	}
+	return dirNames[d]
+}
+
+// Move returns the position one step into the given direction.
+func (v Vec2i) Move(d Dir) Vec2i {
+	return v.Add(d.Vec())
+}
+
+// MulDir rotates a direction, in screen coordinates NewRotCW turns like
+// Dir.TurnRight.
+func (s SquareMat2i) MulDir(d Dir) Dir {
+	r, ok := DirOf(s.Mul(d.Vec()))
+	if !ok {
+		panic(fmt.Errorf("%v does not map %s onto a direction", s, d))
+	}
+	return r
+}
@@ -31,3 +31,53 @@ func (s SquareMat2i) Mul(i Vec2i) Vec2i {
 		Y: s.A21*i.X + s.A22*i.Y,
 	}
 }
+
+// Mat2i is the general name of SquareMat2i.
+type Mat2i = SquareMat2i
+
+func Identity2i() SquareMat2i {
+	return SquareMat2i{A11: 1, A22: 1}
+}
+
+// MulMat returns the matrix product s·o.
+func (s SquareMat2i) MulMat(o SquareMat2i) SquareMat2i {
+	return SquareMat2i{
+		A11: s.A11*o.A11 + s.A12*o.A21,
+		A12: s.A11*o.A12 + s.A12*o.A22,
+		A21: s.A21*o.A11 + s.A22*o.A21,
+		A22: s.A21*o.A12 + s.A22*o.A22,
+	}
+}
+
+func (s SquareMat2i) Transpose() SquareMat2i {
+	return SquareMat2i{
+		A11: s.A11,
+		A12: s.A21,
+		A21: s.A12,
+		A22: s.A22,
+	}
+}
+
+func (s SquareMat2i) Det() int {
+	return s.A11*s.A22 - s.A12*s.A21
+}
+
+// Pow raises the matrix to the n-th power by squaring, n must not be negative.
+func (s SquareMat2i) Pow(n int) SquareMat2i {
+	return pow(s, n, Identity2i(), SquareMat2i.MulMat)
+}
+
+// PowMod raises the matrix to the n-th power with all entries reduced
+// modulo m, e.g. to advance a linear recurrence by a huge number of steps.
+func (s SquareMat2i) PowMod(n, m int) SquareMat2i {
+	mulMod := func(a, b SquareMat2i) SquareMat2i {
+		return SquareMat2i{
+			A11: addMod(mulMod(a.A11, b.A11, m), mulMod(a.A12, b.A21, m), m),
+			A12: addMod(mulMod(a.A11, b.A12, m), mulMod(a.A12, b.A22, m), m),
+			A21: addMod(mulMod(a.A21, b.A11, m), mulMod(a.A22, b.A21, m), m),
+			A22: addMod(mulMod(a.A21, b.A12, m), mulMod(a.A22, b.A22, m), m),
+		}
+	}
+	reduced := SquareMat2i{A11: mod(s.A11, m), A12: mod(s.A12, m), A21: mod(s.A21, m), A22: mod(s.A22, m)}
+	return pow(reduced, n, SquareMat2i{A11: mod(1, m), A22: mod(1, m)}, mulMod)
+}
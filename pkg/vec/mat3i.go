@@ -0,0 +1,153 @@
+package vec
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// Mat3i is a 3x3 integer matrix indexed by row and column.
+type Mat3i [3][3]int
+
+func Identity3i() Mat3i {
+	return Mat3i{
+		{1, 0, 0},
+		{0, 1, 0},
+		{0, 0, 1},
+	}
+}
+
+func (s Mat3i) Mul(v Vec3i) Vec3i {
+	return Vec3i{
+		X: s[0][0]*v.X + s[0][1]*v.Y + s[0][2]*v.Z,
+		Y: s[1][0]*v.X + s[1][1]*v.Y + s[1][2]*v.Z,
+		Z: s[2][0]*v.X + s[2][1]*v.Y + s[2][2]*v.Z,
+	}
+}
+
+// MulMat returns the matrix product s·o.
+func (s Mat3i) MulMat(o Mat3i) Mat3i {
+	var r Mat3i
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			for k := 0; k < 3; k++ {
+				r[i][j] += s[i][k] * o[k][j]
+			}
+		}
+	}
+	return r
+}
+
+func (s Mat3i) Transpose() Mat3i {
+	var r Mat3i
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			r[i][j] = s[j][i]
+		}
+	}
+	return r
+}
+
+func (s Mat3i) Det() int {
+	return s[0][0]*(s[1][1]*s[2][2]-s[1][2]*s[2][1]) -
+		s[0][1]*(s[1][0]*s[2][2]-s[1][2]*s[2][0]) +
+		s[0][2]*(s[1][0]*s[2][1]-s[1][1]*s[2][0])
+}
+
+// Pow raises the matrix to the n-th power by squaring, n must not be negative.
+func (s Mat3i) Pow(n int) Mat3i {
+	return pow(s, n, Identity3i(), Mat3i.MulMat)
+}
+
+// PowMod raises the matrix to the n-th power with all entries reduced
+// modulo m, e.g. to advance a linear recurrence by a huge number of steps.
+func (s Mat3i) PowMod(n, m int) Mat3i {
+	mulMod3 := func(a, b Mat3i) Mat3i {
+		var r Mat3i
+		for i := 0; i < 3; i++ {
+			for j := 0; j < 3; j++ {
+				for k := 0; k < 3; k++ {
+					r[i][j] = addMod(r[i][j], mulMod(a[i][k], b[k][j], m), m)
+				}
+			}
+		}
+		return r
+	}
+
+	var reduced, identity Mat3i
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			reduced[i][j] = mod(s[i][j], m)
+		}
+		identity[i][i] = mod(1, m)
+	}
+	return pow(reduced, n, identity, mulMod3)
+}
+
+func (s Mat3i) String() string {
+	return fmt.Sprintf("%v", [3][3]int(s))
+}
+
+// Rotations3i returns all 24 proper rotations of the cube, i.e. the signed
+// permutation matrices with a determinant of 1. The identity comes first.
+func Rotations3i() []Mat3i {
+	permutations := [][3]int{
+		{0, 1, 2}, {0, 2, 1}, {1, 0, 2}, {1, 2, 0}, {2, 0, 1}, {2, 1, 0},
+	}
+
+	var rotations []Mat3i
+	for _, p := range permutations {
+		for signs := 0; signs < 8; signs++ {
+			var m Mat3i
+			for row, col := range p {
+				m[row][col] = 1
+				if signs&(1<<row) != 0 {
+					m[row][col] = -1
+				}
+			}
+			if m.Det() == 1 {
+				rotations = append(rotations, m)
+			}
+		}
+	}
+	return rotations
+}
+
+// pow computes base^n by squaring
+func pow[M any](base M, n int, identity M, mul func(a, b M) M) M {
+	if n < 0 {
+		panic(fmt.Errorf("negative exponent: %d", n))
+	}
+
+	result := identity
+	for n > 0 {
+		if n&1 == 1 {
+			result = mul(result, base)
+		}
+		base = mul(base, base)
+		n >>= 1
+	}
+	return result
+}
+
+func mod(a, m int) int {
+	a %= m
+	if a < 0 {
+		a += m
+	}
+	return a
+}
+
+func addMod(a, b, m int) int {
+	s := a + b
+	if s >= m {
+		s -= m
+	}
+	return s
+}
+
+// mulMod multiplies a and b in [0, m) without overflowing
+func mulMod(a, b, m int) int {
+	hi, lo := bits.Mul64(uint64(a), uint64(b))
+	_, rem := bits.Div64(hi, lo, uint64(m))
+	return int(rem)
+}
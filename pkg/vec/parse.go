@@ -0,0 +1,28 @@
+package vec
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+func parseInts(s string, n int) ([]int, error) {
+	trimmed := strings.Trim(strings.TrimSpace(s), "{}()[]<>")
+	fields := strings.FieldsFunc(trimmed, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+	if len(fields) != n {
+		return nil, fmt.Errorf("expected %d components, got %d: %q", n, len(fields), s)
+	}
+
+	components := make([]int, n)
+	for i, f := range fields {
+		c, err := strconv.Atoi(f)
+		if err != nil {
+			return nil, fmt.Errorf("invalid component %q: %w", s, err)
+		}
+		components[i] = c
+	}
+	return components, nil
+}
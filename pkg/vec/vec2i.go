@@ -23,25 +23,133 @@ func (v Vec2i) Sub(subtrahend Vec2i) Vec2i {
 	}
 }
 
+func (v Vec2i) Scale(factor int) Vec2i {
+	return Vec2i{
+		X: v.X * factor,
+		Y: v.Y * factor,
+	}
+}
+
+func (v Vec2i) Neg() Vec2i {
+	return Vec2i{X: -v.X, Y: -v.Y}
+}
+
+func (v Vec2i) Dot(o Vec2i) int {
+	return v.X*o.X + v.Y*o.Y
+}
+
+// PerpDot is the z component of the cross product of both vectors lifted to
+// 3D, positive if o is counterclockwise of v.
+func (v Vec2i) PerpDot(o Vec2i) int {
+	return v.X*o.Y - v.Y*o.X
+}
+
+// Min returns the componentwise minimum.
+func (v Vec2i) Min(o Vec2i) Vec2i {
+	return Vec2i{X: min(v.X, o.X), Y: min(v.Y, o.Y)}
+}
+
+// Max returns the componentwise maximum.
+func (v Vec2i) Max(o Vec2i) Vec2i {
+	return Vec2i{X: max(v.X, o.X), Y: max(v.Y, o.Y)}
+}
+
+// Sign returns the componentwise sign, each component is one of -1, 0 or 1.
+func (v Vec2i) Sign() Vec2i {
+	return Vec2i{X: Sign(v.X), Y: Sign(v.Y)}
+}
+
 func (v Vec2i) Abs() float32 {
 	return float32(math.Sqrt(float64(v.X*v.X + v.Y*v.Y)))
 }
 
 func (v Vec2i) Norm1() int {
-	return abs(v.X) + abs(v.Y)
+	return Abs(v.X) + Abs(v.Y)
+}
+
+// Manhattan returns the L1 distance to o.
+func (v Vec2i) Manhattan(o Vec2i) int {
+	return v.Sub(o).Norm1()
+}
+
+// Chebyshev returns the L∞ distance to o, i.e. the number of king moves.
+func (v Vec2i) Chebyshev(o Vec2i) int {
+	d := v.Sub(o)
+	return max(Abs(d.X), Abs(d.Y))
 }
 
-func abs(i int) int {
+var (
+	neighbors4 = []Vec2i{{X: 0, Y: -1}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: -1, Y: 0}}
+	neighbors8 = []Vec2i{
+		{X: -1, Y: -1}, {X: 0, Y: -1}, {X: 1, Y: -1},
+		{X: -1, Y: 0}, {X: 1, Y: 0},
+		{X: -1, Y: 1}, {X: 0, Y: 1}, {X: 1, Y: 1},
+	}
+)
+
+// Neighbors4 returns the four orthogonally adjacent positions, clockwise
+// starting with negative Y.
+func (v Vec2i) Neighbors4() []Vec2i {
+	return v.translateAll(neighbors4)
+}
+
+// Neighbors8 returns the eight orthogonally and diagonally adjacent
+// positions in row-major order.
+func (v Vec2i) Neighbors8() []Vec2i {
+	return v.translateAll(neighbors8)
+}
+
+func (v Vec2i) translateAll(offsets []Vec2i) []Vec2i {
+	r := make([]Vec2i, len(offsets))
+	for i, o := range offsets {
+		r[i] = v.Add(o)
+	}
+	return r
+}
+
+// Abs returns the absolute value of i.
+func Abs(i int) int {
 	if i < 0 {
 		i = -i
 	}
 	return i
 }
 
+// FloorDiv divides a by b rounding towards negative infinity, unlike a / b
+// which truncates towards zero.
+func FloorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+// Sign returns -1, 0 or 1 for negative, zero and positive i.
+func Sign(i int) int {
+	switch {
+	case i < 0:
+		return -1
+	case i > 0:
+		return 1
+	}
+	return 0
+}
+
 func (v Vec2i) String() string {
 	return fmt.Sprintf("{%d,%d}", v.X, v.Y)
 }
 
+// ParseVec2i parses two integers separated by a comma and/or whitespace,
+// optionally wrapped in braces or parentheses, e.g. "3,-4" or "{3, -4}".
+func ParseVec2i(s string) (Vec2i, error) {
+	c, err := parseInts(s, 2)
+	if err != nil {
+		return Vec2i{}, err
+	}
+	return Vec2i{X: c[0], Y: c[1]}, nil
+}
+
 func Compare2i(a, b Vec2i) int {
 	d := cmp.Compare(a.X, b.X)
 	if d != 0 {
@@ -23,14 +23,109 @@ func (v Vec3i) Sub(subtrahend Vec3i) Vec3i {
 	}
 }
 
+func (v Vec3i) Scale(factor int) Vec3i {
+	return Vec3i{
+		X: v.X * factor,
+		Y: v.Y * factor,
+		Z: v.Z * factor,
+	}
+}
+
+func (v Vec3i) Neg() Vec3i {
+	return Vec3i{X: -v.X, Y: -v.Y, Z: -v.Z}
+}
+
+func (v Vec3i) Dot(o Vec3i) int {
+	return v.X*o.X + v.Y*o.Y + v.Z*o.Z
+}
+
+func (v Vec3i) Cross(o Vec3i) Vec3i {
+	return Vec3i{
+		X: v.Y*o.Z - v.Z*o.Y,
+		Y: v.Z*o.X - v.X*o.Z,
+		Z: v.X*o.Y - v.Y*o.X,
+	}
+}
+
+// Min returns the componentwise minimum.
+func (v Vec3i) Min(o Vec3i) Vec3i {
+	return Vec3i{X: min(v.X, o.X), Y: min(v.Y, o.Y), Z: min(v.Z, o.Z)}
+}
+
+// Max returns the componentwise maximum.
+func (v Vec3i) Max(o Vec3i) Vec3i {
+	return Vec3i{X: max(v.X, o.X), Y: max(v.Y, o.Y), Z: max(v.Z, o.Z)}
+}
+
+// Sign returns the componentwise sign, each component is one of -1, 0 or 1.
+func (v Vec3i) Sign() Vec3i {
+	return Vec3i{X: Sign(v.X), Y: Sign(v.Y), Z: Sign(v.Z)}
+}
+
 func (v Vec3i) Abs() float32 {
 	return float32(math.Sqrt(float64(v.X*v.X + v.Y*v.Y + v.Z*v.Z)))
 }
 
 func (v Vec3i) Norm1() int {
-	return abs(v.X) + abs(v.Y) + abs(v.Z)
+	return Abs(v.X) + Abs(v.Y) + Abs(v.Z)
+}
+
+// Manhattan returns the L1 distance to o.
+func (v Vec3i) Manhattan(o Vec3i) int {
+	return v.Sub(o).Norm1()
+}
+
+// Chebyshev returns the L∞ distance to o.
+func (v Vec3i) Chebyshev(o Vec3i) int {
+	d := v.Sub(o)
+	return max(Abs(d.X), Abs(d.Y), Abs(d.Z))
+}
+
+var (
+	neighbors6  = []Vec3i{{X: -1}, {X: 1}, {Y: -1}, {Y: 1}, {Z: -1}, {Z: 1}}
+	neighbors26 = func() []Vec3i {
+		var offsets []Vec3i
+		for z := -1; z <= 1; z++ {
+			for y := -1; y <= 1; y++ {
+				for x := -1; x <= 1; x++ {
+					if x != 0 || y != 0 || z != 0 {
+						offsets = append(offsets, Vec3i{X: x, Y: y, Z: z})
+					}
+				}
+			}
+		}
+		return offsets
+	}()
+)
+
+// Neighbors6 returns the six positions sharing a face.
+func (v Vec3i) Neighbors6() []Vec3i {
+	return v.translateAll(neighbors6)
+}
+
+// Neighbors26 returns all positions sharing a face, edge or corner.
+func (v Vec3i) Neighbors26() []Vec3i {
+	return v.translateAll(neighbors26)
+}
+
+func (v Vec3i) translateAll(offsets []Vec3i) []Vec3i {
+	r := make([]Vec3i, len(offsets))
+	for i, o := range offsets {
+		r[i] = v.Add(o)
+	}
+	return r
 }
 
 func (v Vec3i) String() string {
 	return fmt.Sprintf("{%d,%d,%d}", v.X, v.Y, v.Z)
 }
+
+// ParseVec3i parses three integers separated by commas and/or whitespace,
+// optionally wrapped in braces or parentheses, e.g. "1,2,3" or "{1, 2, 3}".
+func ParseVec3i(s string) (Vec3i, error) {
+	c, err := parseInts(s, 3)
+	if err != nil {
+		return Vec3i{}, err
+	}
+	return Vec3i{X: c[0], Y: c[1], Z: c[2]}, nil
+}
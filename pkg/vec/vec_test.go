@@ -0,0 +1,215 @@
+package vec
+
+import (
+	"testing"
+
+	"aoc/pkg/be"
+	"aoc/pkg/util"
+)
+
+func TestVec2i(t *testing.T) {
+	a := Vec2i{X: 3, Y: -4}
+	b := Vec2i{X: -1, Y: 2}
+
+	be.Equal(t, a.Scale(2), Vec2i{X: 6, Y: -8})
+	be.Equal(t, a.Neg(), Vec2i{X: -3, Y: 4})
+	be.Equal(t, a.Dot(b), -11)
+	be.Equal(t, a.PerpDot(b), 2)
+	be.Equal(t, a.Min(b), Vec2i{X: -1, Y: -4})
+	be.Equal(t, a.Max(b), Vec2i{X: 3, Y: 2})
+	be.Equal(t, a.Sign(), Vec2i{X: 1, Y: -1})
+	be.Equal(t, a.Manhattan(b), 10)
+	be.Equal(t, a.Chebyshev(b), 6)
+}
+
+func TestFloorDiv(t *testing.T) {
+	be.Equal(t, FloorDiv(7, 2), 3)
+	be.Equal(t, FloorDiv(-7, 2), -4)
+	be.Equal(t, FloorDiv(7, -2), -4)
+	be.Equal(t, FloorDiv(-7, -2), 3)
+	be.Equal(t, FloorDiv(-6, 2), -3)
+	be.Equal(t, FloorDiv(0, 5), 0)
+}
+
+func TestVec2i_Neighbors(t *testing.T) {
+	p := Vec2i{X: 5, Y: 5}
+
+	for _, n := range p.Neighbors4() {
+		be.Equal(t, p.Manhattan(n), 1)
+	}
+
+	n8 := p.Neighbors8()
+	be.Equal(t, len(n8), 8)
+	for _, n := range n8 {
+		be.Equal(t, p.Chebyshev(n), 1)
+	}
+}
+
+func TestVec3i(t *testing.T) {
+	x := Vec3i{X: 1}
+	y := Vec3i{Y: 1}
+
+	be.Equal(t, x.Cross(y), Vec3i{Z: 1})
+	be.Equal(t, y.Cross(x), Vec3i{Z: -1})
+	be.Equal(t, Vec3i{X: 1, Y: 2, Z: 3}.Dot(Vec3i{X: 4, Y: 5, Z: 6}), 32)
+	be.Equal(t, Vec3i{X: 1, Y: -7, Z: 3}.Chebyshev(Vec3i{}), 7)
+
+	be.Equal(t, len(x.Neighbors6()), 6)
+	be.Equal(t, len(x.Neighbors26()), 26)
+}
+
+func TestParse(t *testing.T) {
+	v, err := ParseVec2i("{3, -4}")
+	be.NoError(t, err)
+	be.Equal(t, v, Vec2i{X: 3, Y: -4})
+
+	w, err := ParseVec3i("162,817,812")
+	be.NoError(t, err)
+	be.Equal(t, w, Vec3i{X: 162, Y: 817, Z: 812})
+
+	_, err = ParseVec2i("1,2,3")
+	be.AnError(t, err)
+
+	_, err = ParseVec3i("a,b,c")
+	be.AnError(t, err)
+}
+
+func TestDir(t *testing.T) {
+	be.Equal(t, N.TurnRight(), E)
+	be.Equal(t, W.TurnRight(), N)
+	be.Equal(t, N.TurnLeft(), W)
+	be.Equal(t, NE.Reverse(), SW)
+	be.Equal(t, NW.Rotate(1), N)
+	be.Equal(t, N.Rotate(-3), SW)
+	be.Equal(t, SE.Vec(), Vec2i{X: 1, Y: 1})
+	be.Equal(t, Vec2i{X: 3, Y: 3}.Move(N), Vec2i{X: 3, Y: 2})
+	be.Equal(t, S.String(), "S")
+
+	for _, d := range Dirs {
+		be.Equal(t, d.Vec().Add(d.Reverse().Vec()), Vec2i{})
+
+		back, ok := DirOf(d.Vec())
+		be.True(t, ok)
+		be.Equal(t, back, d)
+	}
+}
+
+func TestDir_MatchesRotation(t *testing.T) {
+	for _, d := range Dirs {
+		be.Equal(t, NewRotCW().MulDir(d), d.TurnRight())
+		be.Equal(t, NewRotCCW().MulDir(d), d.TurnLeft())
+	}
+}
+
+func TestParseDir(t *testing.T) {
+	for _, r := range "^UN" {
+		be.Equal(t, util.Must(ParseDir(r)), N)
+	}
+	for _, r := range ">RE" {
+		be.Equal(t, util.Must(ParseDir(r)), E)
+	}
+	for _, r := range "vDS" {
+		be.Equal(t, util.Must(ParseDir(r)), S)
+	}
+	for _, r := range "<LW" {
+		be.Equal(t, util.Must(ParseDir(r)), W)
+	}
+
+	_, err := ParseDir('x')
+	be.AnError(t, err)
+}
+
+func TestAABB(t *testing.T) {
+	a := NewAABBFromSize(Vec2i{X: 0, Y: 0}, Vec2i{X: 4, Y: 3})
+	b := AABB{From: Vec2i{X: 2, Y: 1}, To: Vec2i{X: 6, Y: 6}}
+
+	be.Equal(t, a.To, Vec2i{X: 3, Y: 2})
+	be.Equal(t, a.Area(), 12)
+	be.Equal(t, a.Size(), Vec2i{X: 4, Y: 3})
+
+	be.True(t, a.Overlaps(b))
+	be.Equal(t, a.Intersect(b), AABB{From: Vec2i{X: 2, Y: 1}, To: Vec2i{X: 3, Y: 2}})
+	be.Equal(t, a.Union(b), AABB{From: Vec2i{}, To: Vec2i{X: 6, Y: 6}})
+
+	c := a.Translate(Vec2i{X: 10})
+	be.True(t, !a.Overlaps(c))
+	be.True(t, a.Intersect(c).IsEmpty())
+	be.Equal(t, a.Intersect(c).Area(), 0)
+
+	be.Equal(t, a.Expand(1).Area(), 30)
+	be.Equal(t, a.Clamp(Vec2i{X: -5, Y: 7}), Vec2i{X: 0, Y: 2})
+	be.Equal(t, a.Extend(Vec2i{X: 5, Y: 5}).To, Vec2i{X: 5, Y: 5})
+
+	points := a.Points()
+	be.Equal(t, len(points), 12)
+	be.Equal(t, points[1], Vec2i{X: 1, Y: 0})
+	be.Equal(t, points[4], Vec2i{X: 0, Y: 1})
+}
+
+func TestAABB3i(t *testing.T) {
+	a := NewAABB3iFromSize(Vec3i{}, Vec3i{X: 2, Y: 2, Z: 3})
+	b := AABB3i{From: Vec3i{X: 1, Y: 1, Z: 2}, To: Vec3i{X: 1, Y: 1, Z: 5}}
+
+	be.Equal(t, a.Volume(), 12)
+	be.True(t, a.Overlaps(b))
+	be.Equal(t, a.Intersect(b).Volume(), 1)
+	be.True(t, !a.Overlaps(b.Translate(Vec3i{Z: 1})))
+	be.Equal(t, a.Union(b).Size(), Vec3i{X: 2, Y: 2, Z: 6})
+
+	box := BoundingBox3i([]Vec3i{{X: 1, Y: 5, Z: -1}, {X: -2, Y: 0, Z: 3}})
+	be.Equal(t, box, AABB3i{From: Vec3i{X: -2, Y: 0, Z: -1}, To: Vec3i{X: 1, Y: 5, Z: 3}})
+
+	count := 0
+	box.ForEach(func(p Vec3i) { count++ })
+	be.Equal(t, count, box.Volume())
+}
+
+func TestMat2i(t *testing.T) {
+	rot := NewRotCW()
+
+	be.Equal(t, rot.Pow(4), Identity2i())
+	be.Equal(t, rot.MulMat(NewRotCCW()), Identity2i())
+	be.Equal(t, rot.Transpose(), NewRotCCW())
+	be.Equal(t, rot.Det(), 1)
+
+	// fibonacci
+	fib := Mat2i{A11: 1, A12: 1, A21: 1, A22: 0}
+	be.Equal(t, fib.Pow(10).A12, 55)
+	be.Equal(t, fib.PowMod(90, 1_000_000_007).A12, 2880067194370816120%1_000_000_007)
+	be.Equal(t, fib.PowMod(0, 7), Identity2i())
+}
+
+func TestMat3i(t *testing.T) {
+	m := Mat3i{
+		{2, 0, 1},
+		{1, 3, 2},
+		{1, 1, 2},
+	}
+	be.Equal(t, m.Det(), 6)
+	be.Equal(t, m.MulMat(Identity3i()), m)
+	be.Equal(t, m.Transpose().Transpose(), m)
+	be.Equal(t, m.Pow(3), m.MulMat(m).MulMat(m))
+	be.Equal(t, m.Mul(Vec3i{X: 1, Y: 2, Z: 3}), Vec3i{X: 5, Y: 13, Z: 9})
+
+	pm := m.PowMod(3, 5)
+	p := m.Pow(3)
+	for i := range p {
+		for j := range p[i] {
+			be.Equal(t, pm[i][j], p[i][j]%5)
+		}
+	}
+}
+
+func TestRotations3i(t *testing.T) {
+	rotations := Rotations3i()
+	be.Equal(t, len(rotations), 24)
+	be.Equal(t, rotations[0], Identity3i())
+
+	// all rotations map a asymmetric vector onto distinct images
+	images := map[Vec3i]bool{}
+	for _, r := range rotations {
+		images[r.Mul(Vec3i{X: 1, Y: 2, Z: 3})] = true
+		be.Equal(t, r.MulMat(r.Transpose()), Identity3i())
+	}
+	be.Equal(t, len(images), 24)
+}